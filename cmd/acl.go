@@ -0,0 +1,132 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/acl"
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(cisco-mac-acl, junos-filter, nftables, iptables, ebtables, wireshark-filter, bpf).
+type ACLTarget string //nolint: recvcheck // generated by a third-party
+
+// ENUM(allow, deny).
+type ACLAction string //nolint: recvcheck // generated by a third-party
+
+var (
+	flagACLTarget ACLTarget
+	flagACLAction = ACLActionAllow
+)
+
+var aclRenderers = map[ACLTarget]acl.Renderer{
+	ACLTargetCiscoMacAcl:     acl.CiscoMACACL,
+	ACLTargetJunosFilter:     acl.JunosFilter,
+	ACLTargetNftables:        acl.Nftables,
+	ACLTargetIptables:        acl.Iptables,
+	ACLTargetEbtables:        acl.Ebtables,
+	ACLTargetWiresharkFilter: acl.WiresharkFilter,
+	ACLTargetBpf:             acl.BPF,
+}
+
+var aclCmd = &cobra.Command{
+	Use:   "acl --org regexp --target target",
+	Short: "Generate MAC access lists and filters for all assignments of a vendor",
+	Long: `Generate MAC access lists and filters matching source MACs of a vendor. Every
+MA-L, MA-M and MA-S assignment of organizations whose name matches --org (a case
+insensitive regular expression) becomes a MAC and a mask. Assignments covered
+by others are dropped and adjacent ones are merged, e.g. 001B20 and 001B21 into
+00:1b:20:00:00:00/23. Supported targets (--target):
+
+cisco-mac-acl     mac access-list extended of IOS/NX-OS named by --name
+junos-filter      set commands of an ethernet-switching filter named by --name
+nftables          rules for a chain of a bridge, inet or netdev table
+iptables          iptables-restore rules for --chain using the bpf match, only
+                  packets received from Ethernet carry the source MAC
+ebtables          ebtables rules for --chain
+wireshark-filter  a display filter
+bpf               a pcap-filter expression for tcpdump
+
+--action allow or deny selects what rules do with matching frames, filters of
+wireshark-filter and bpf match the vendor either way. CIDs are left out as they
+are not used in universally administered MACs. Requires the OUI database, see
+'oui update'.`,
+	Args:         cobra.ExactArgs(0),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		org, err := orgFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		chain, err := cmd.Flags().GetString("chain")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		opts := acl.Options{Deny: flagACLAction == ACLActionDeny, Name: name, Chain: chain}
+
+		return aclAction(cmd.OutOrStdout(), trie, org, flagACLTarget, opts)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(aclCmd)
+	aclCmd.Flags().String("org", "", "regular expression matching names of organizations")
+	aclCmd.Flags().Var(
+		&flagACLTarget,
+		"target",
+		"format of the access list, permitted options: "+strings.Join(ACLTargetNames(), ", "),
+	)
+	aclCmd.Flags().Var(
+		&flagACLAction,
+		"action",
+		"what rules do with matching frames, permitted options: "+strings.Join(ACLActionNames(), ", "),
+	)
+	aclCmd.Flags().String("name", "VENDOR", "name of a Cisco access list or a Junos filter")
+	aclCmd.Flags().String("chain", "FORWARD", "chain of iptables and ebtables rules")
+	_ = aclCmd.MarkFlagRequired("org")
+	_ = aclCmd.MarkFlagRequired("target")
+}
+
+func aclAction(w io.Writer, trie *registry.Trie, org *regexp.Regexp, target ACLTarget, opts acl.Options) error {
+	records, err := organizationRecords(trie, org)
+	if err != nil {
+		return err
+	}
+
+	blocks := make([]hwaddr.Block, 0, len(records))
+	for _, record := range records {
+		block, perr := hwaddr.ParseBlock(record.Assignment)
+		if perr != nil {
+			return fmt.Errorf("assignment of %s: %w", record.OrgName, perr)
+		}
+		blocks = append(blocks, block)
+	}
+
+	_, err = io.WriteString(w, aclRenderers[target](acl.Aggregate(blocks), opts))
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -33,7 +33,7 @@ var addr6Cmd = &cobra.Command{
 			r = cmd.InOrStdin()
 		}
 
-		return addr6Action(cmd.OutOrStdout(), r)
+		return addr6Action(cmd.OutOrStdout(), r, newLineErrors(flagOnError, cmd.ErrOrStderr()))
 	},
 }
 
@@ -41,7 +41,7 @@ func init() {
 	euiCmd.AddCommand(addr6Cmd)
 }
 
-func addr6Action(w io.Writer, r io.Reader) error {
+func addr6Action(w io.Writer, r io.Reader, lineErrs *lineErrors) error {
 	const numFields = 2
 	scanner := bufio.NewScanner(r)
 	writer := bufio.NewWriter(w)
@@ -53,7 +53,11 @@ func addr6Action(w io.Writer, r io.Reader) error {
 		line := scanner.Text()
 		lineFields := strings.Fields(line)
 		if len(lineFields) != numFields {
-			return fmt.Errorf("expected %d fields, got %d in %q", numFields, len(lineFields), line)
+			err := fmt.Errorf("expected %d fields, got %d in %q", numFields, len(lineFields), line)
+			if err = lineErrs.fail(writer, lineN, 1, line, err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		prefixRaw := lineFields[0]
@@ -61,12 +65,19 @@ func addr6Action(w io.Writer, r io.Reader) error {
 
 		prefix, err := netip.ParsePrefix(prefixRaw)
 		if err != nil {
-			return AtInputPositionError{Position: lineN, Err: err}
+			if err = lineErrs.fail(writer, lineN, columnOf(line, prefixRaw), line, err); err != nil {
+				return err
+			}
+			continue
 		}
 		eui, err := hwaddr.ParseAddr(euiRaw)
 		if err != nil {
-			return AtInputPositionError{Position: lineN, Err: err}
+			if err = lineErrs.fail(writer, lineN, columnOf(line, euiRaw), line, err); err != nil {
+				return err
+			}
+			continue
 		}
+		lineErrs.ok()
 
 		var eui64 hwaddr.EUI64
 
@@ -74,10 +85,7 @@ func addr6Action(w io.Writer, r io.Reader) error {
 			eui48, _ := hwaddr.EUI48FromBytes(eui)
 			eui64 = eui48.EUI64Modified()
 		} else {
-			eui64, err = hwaddr.EUI64FromBytes(eui)
-			if err != nil {
-				return AtInputPositionError{Position: lineN, Err: err}
-			}
+			eui64, _ = hwaddr.EUI64FromBytes(eui)
 		}
 
 		addr := hwaddr.AppendToPrefix(prefix, eui64)
@@ -96,5 +104,5 @@ func addr6Action(w io.Writer, r io.Reader) error {
 		return berrors.WithStack(err)
 	}
 
-	return nil
+	return lineErrs.result()
 }
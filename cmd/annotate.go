@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+const defaultAnnotateTemplate = "  # {{.Vendor}}"
+
+// AnnotateData is available to the template of 'oui annotate'.
+type AnnotateData struct {
+	Text       string // the EUI as found in the text
+	Vendor     string
+	Assignment string
+	Registry   string
+}
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate [text ...]",
+	Short: "Append vendor names to lines of arbitrary text",
+	Long: `Pass text through appending the name of the organization owning each EUI
+found to the end of its line, so output of commands like 'ip neigh', 'arp -an'
+or 'show mac address-table' can be read with vendors inline. EUIs that are not
+registered, e.g. locally administered ones, are not annotated.
+
+The annotation is a Go text/template rendered for every EUI of a line, fields:
+.Text (the EUI as found in the text), .Vendor, .Assignment and .Registry. The
+default is "` + defaultAnnotateTemplate + `". Requires the OUI database, see 'oui update'.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		text, err := cmd.Flags().GetString("template")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		tmpl, err := template.New("annotation").Parse(text)
+		if err != nil {
+			return fmt.Errorf("parsing template: %w", err)
+		}
+		// Catch references to unknown fields before reading any input.
+		err = tmpl.Execute(io.Discard, AnnotateData{Text: "", Vendor: "", Assignment: "", Registry: ""})
+		if err != nil {
+			return fmt.Errorf("executing template: %w", err)
+		}
+
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		return annotateAction(cmd.OutOrStdout(), r, tmpl, trie)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(annotateCmd)
+	annotateCmd.Flags().String("template", defaultAnnotateTemplate, "annotation appended for every EUI of a line")
+}
+
+func annotateAction(w io.Writer, r io.Reader, tmpl *template.Template, trie *registry.Trie) error {
+	var tmplErr error
+
+	err := rewriteLines(w, r, func(line string) string {
+		body, ending := splitLineEnding(line)
+
+		var buf strings.Builder
+		buf.WriteString(body)
+
+		for _, m := range hwaddr.FindAll(body) {
+			records := trie.LongestPrefixMatch(hexPrefix(m.Addr))
+			if len(records) == 0 {
+				continue
+			}
+
+			data := AnnotateData{
+				Text:       m.Text,
+				Vendor:     orgNames(records),
+				Assignment: records[0].Assignment,
+				Registry:   string(records[0].Registry),
+			}
+			if err := tmpl.Execute(&buf, data); err != nil && tmplErr == nil {
+				tmplErr = err
+			}
+		}
+
+		return buf.String() + ending
+	})
+	if err != nil {
+		return err
+	}
+	if tmplErr != nil {
+		return fmt.Errorf("executing template: %w", tmplErr)
+	}
+
+	return nil
+}
+
+// splitLineEnding separates a trailing "\n" or "\r\n" from a line.
+func splitLineEnding(line string) (string, string) {
+	body := strings.TrimSuffix(line, "\n")
+	if body == line {
+		return line, ""
+	}
+	body = strings.TrimSuffix(body, "\r")
+
+	return body, line[len(body):]
+}
@@ -0,0 +1,184 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(HMAC, MASK).
+type AnonymizeMode string //nolint: recvcheck // generated by a third-party
+
+var flagAnonymizeMode = AnonymizeModeHMAC
+
+var errMissingKey = errors.New("missing anonymization key")
+
+type anonymizeOptions struct {
+	mode   AnonymizeMode
+	key    []byte
+	opts   hwaddr.PseudonymizeOptions
+	format *EUIFormat // nil preserves the notation of the input
+	table  tableOptions
+}
+
+var anonymizeCmd = &cobra.Command{
+	Use:   "anonymize [text ...]",
+	Short: "Pseudonymize or mask EUIs inside a text stream",
+	Long: `Pseudonymize or mask EUIs inside a text stream leaving the rest of the text
+untouched.
+
+The hmac mode replaces each EUI with a keyed HMAC-SHA256 of it, the mapping is
+consistent across runs for the same key. The key is read from --key-file or
+from the anonymize-key option (EUIVATOR_ANONYMIZE_KEY). --preserve-oui keeps
+the OUI so vendor analytics still work, --preserve-bits keeps the I/G and U/L
+bits. The mask mode zeroes everything but the OUI.
+
+Rewritten EUIs keep the notation of the input unless --format is set. With
+--field or --field-name only EUIs in a given column are rewritten.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		opts, err := anonymizeOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return anonymizeAction(cmd.OutOrStdout(), r, opts, newLineErrors(flagOnError, cmd.ErrOrStderr()))
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(anonymizeCmd)
+	anonymizeCmd.Flags().Var(
+		&flagAnonymizeMode,
+		"mode",
+		"permitted options: "+strings.Join(AnonymizeModeNames(), ", ")+" (case insensitive)",
+	)
+	anonymizeCmd.Flags().String("key-file", "", "file with the HMAC key")
+	anonymizeCmd.Flags().Bool("preserve-oui", false, "keep the OUI of pseudonymized EUIs")
+	anonymizeCmd.Flags().Bool("preserve-bits", false, "keep the I/G and U/L bits of pseudonymized EUIs")
+	addTableFlags(anonymizeCmd)
+}
+
+func anonymizeOptionsFromFlags(cmd *cobra.Command) (anonymizeOptions, error) {
+	flags := cmd.Flags()
+
+	opts := anonymizeOptions{
+		mode:   flagAnonymizeMode,
+		key:    nil,
+		opts:   hwaddr.PseudonymizeOptions{PreserveOUI: false, PreserveBits: false},
+		format: nil,
+		table:  tableOptions{field: 0, fieldName: "", header: false, delimiter: 0, jsonPath: nil, jsonSet: nil},
+	}
+
+	var err error
+	if opts.opts.PreserveOUI, err = flags.GetBool("preserve-oui"); err != nil {
+		return anonymizeOptions{}, berrors.WithStack(err)
+	}
+	if opts.opts.PreserveBits, err = flags.GetBool("preserve-bits"); err != nil {
+		return anonymizeOptions{}, berrors.WithStack(err)
+	}
+	if opts.table, err = tableOptionsFromFlags(cmd); err != nil {
+		return anonymizeOptions{}, err
+	}
+	if flags.Changed("format") {
+		opts.format = &flagEUIFormat
+	}
+
+	if opts.mode != AnonymizeModeHMAC {
+		return opts, nil
+	}
+
+	keyFile, err := flags.GetString("key-file")
+	if err != nil {
+		return anonymizeOptions{}, berrors.WithStack(err)
+	}
+	if keyFile != "" {
+		opts.key, err = os.ReadFile(keyFile)
+		if err != nil {
+			return anonymizeOptions{}, berrors.WithStack(err)
+		}
+		opts.key = bytes.TrimSpace(opts.key)
+	} else {
+		opts.key = []byte(viper.GetString("anonymize-key"))
+	}
+	if len(opts.key) == 0 {
+		return anonymizeOptions{}, fmt.Errorf(
+			"%w: provide --key-file or set EUIVATOR_ANONYMIZE_KEY", errMissingKey,
+		)
+	}
+
+	return opts, nil
+}
+
+func anonymizeAction(w io.Writer, r io.Reader, opts anonymizeOptions, lineErrs *lineErrors) error {
+	replace := func(m hwaddr.Match) string {
+		var addr []byte
+		switch opts.mode {
+		case AnonymizeModeHMAC:
+			addr = hwaddr.Pseudonymize(m.Addr, opts.key, opts.opts)
+		case AnonymizeModeMASK:
+			addr = hwaddr.Mask(m.Addr, hwaddr.OUILen)
+		}
+
+		if opts.format != nil {
+			return convertFuncMap[*opts.format](addr)
+		}
+		return formatLike(addr, m.Text)
+	}
+
+	if !opts.table.enabled() {
+		return rewriteLines(w, r, func(line string) string {
+			return hwaddr.ReplaceAll(line, replace)
+		})
+	}
+
+	return fieldProcessor{
+		table:    opts.table,
+		lineErrs: lineErrs,
+		apply: func(value string) (string, error) {
+			return hwaddr.ReplaceAll(value, replace), nil
+		},
+		annotate: "",
+		quiet:    false,
+	}.run(w, r)
+}
+
+// formatLike formats an address in the notation of a sample, e.g. the text the
+// address was found in.
+func formatLike(addr []byte, sample string) string {
+	var s string
+
+	switch {
+	case len(sample) > 2 && (sample[2] == ':' || sample[2] == '-'):
+		s = hwaddr.ToString(addr, []byte{sample[2]}, 1)
+	case len(sample) > 4 && sample[4] == '.':
+		s = hwaddr.AsDot(addr)
+	default:
+		s = hwaddr.AsPlain(addr)
+	}
+
+	if strings.ContainsAny(sample, "ABCDEF") {
+		s = strings.ToUpper(s)
+	}
+
+	return s
+}
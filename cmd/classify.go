@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type ClassificationResponse struct {
+	Protocol string `json:"protocol"`
+	Label    string `json:"label"`
+	Group    *int   `json:"group,omitempty"`
+}
+
+type ClassifyResponse struct {
+	Input          string                  `json:"input"`
+	InputRaw       string                  `json:"input_raw"`
+	Classification *ClassificationResponse `json:"classification"`
+}
+
+var classifyCmd = &cobra.Command{
+	Use:          "classify [eui48 ...]",
+	Short:        "Classify well-known and protocol EUIs",
+	Long:         classifyResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		return classifyAction(cmd.OutOrStdout(), r, flagEUIFormat)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(classifyCmd)
+}
+
+func classifyAction(w io.Writer, r io.Reader, format EUIFormat) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		line := scanner.Text()
+		addr, err := hwaddr.ParseAddr(line)
+		if err != nil {
+			return AtInputPositionError{Position: lineN, Err: err}
+		}
+
+		data, err := json.Marshal(ClassifyResponse{
+			Input:          convertFunc(addr),
+			InputRaw:       line,
+			Classification: classify(addr),
+		})
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// classify returns nil when an address is not well-known.
+func classify(addr []byte) *ClassificationResponse {
+	c, ok := hwaddr.Classify(addr)
+	if !ok {
+		return nil
+	}
+
+	result := &ClassificationResponse{Protocol: c.Protocol, Label: c.Label, Group: nil}
+	if c.HasGroup {
+		result.Group = &c.Group
+	}
+
+	return result
+}
+
+func classifyResponseExample() string {
+	vrid := 10
+	data, err := json.MarshalIndent(ClassifyResponse{
+		Input:    "00:00:5e:00:01:0a",
+		InputRaw: "0000.5e00.010a",
+		Classification: &ClassificationResponse{
+			Protocol: "VRRP",
+			Label:    "VRRP virtual router (IPv4) VRID 10",
+			Group:    &vrid,
+		},
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Classify well-known and protocol EUIs using a built-in table: broadcast, IANA
+(VRRP, VRRPv6), Cisco HSRP, HSRPv2, GLBP, CDP and PVST+, IEEE 802.1 link-local
+protocols (STP, LACP, LLDP, EAPOL), IP multicast and default blocks of
+hypervisors and container runtimes (VMware, Hyper-V, QEMU, Xen, VirtualBox,
+Docker). Output is a JSON. Example of the output:
+%s
+The group key holds a decoded group number or VRID when applicable. The
+classification key is null when an address is not well-known`, data)
+}
@@ -3,4 +3,5 @@ package cmd
 const (
 	appName    = "euivator"
 	LookupFile = "registry.gob"
+	IndexFile  = "index.gob"
 )
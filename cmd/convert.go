@@ -1,20 +1,17 @@
 package cmd
 
 import (
-	"bufio"
 	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	berrors "github.com/pkg/errors"
-
 	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
 var convertCmd = &cobra.Command{
 	Use:          "convert [eui ...]",
-	Short:        "Convert an EUI to a chosen representation",
+	Short:        "Convert an EUI/IPoIB address to a chosen representation",
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var r io.Reader
@@ -24,44 +21,34 @@ var convertCmd = &cobra.Command{
 			r = cmd.InOrStdin()
 		}
 
-		return convertAction(cmd.OutOrStdout(), r, flagEUIFormat)
+		table, err := tableOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return convertAction(cmd.OutOrStdout(), r, flagEUIFormat, table, newLineErrors(flagOnError, cmd.ErrOrStderr()))
 	},
 }
 
 func init() {
 	euiCmd.AddCommand(convertCmd)
+	addTableFlags(convertCmd)
 }
 
-func convertAction(w io.Writer, r io.Reader, format EUIFormat) error {
-	scanner := bufio.NewScanner(r)
-	writer := bufio.NewWriter(w)
+func convertAction(w io.Writer, r io.Reader, format EUIFormat, table tableOptions, lineErrs *lineErrors) error {
 	convertFunc := convertFuncMap[format]
 
-	var lineN int
-
-	for scanner.Scan() {
-		lineN++
-		line := scanner.Text()
-		addr, err := hwaddr.ParseAddr(line)
-		if err != nil {
-			return AtInputPositionError{Position: lineN, Err: err}
-		}
-
-		converted := convertFunc(addr)
-		_, err = writer.WriteString(converted + "\n")
-		if err != nil {
-			return berrors.WithStack(err)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return berrors.WithStack(err)
-	}
-
-	err := writer.Flush()
-	if err != nil {
-		return berrors.WithStack(err)
-	}
-
-	return nil
+	return fieldProcessor{
+		table:    table,
+		lineErrs: lineErrs,
+		apply: func(value string) (string, error) {
+			addr, err := hwaddr.ParseHardwareAddr(value)
+			if err != nil {
+				return "", err //nolint: wrapcheck // already descriptive
+			}
+			return convertFunc(addr), nil
+		},
+		annotate: "",
+		quiet:    false,
+	}.run(w, r)
 }
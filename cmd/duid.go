@@ -0,0 +1,238 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(ll, llt).
+type DUIDKind string //nolint: recvcheck // generated by a third-party
+
+// The zero value means that DUIDs are parsed rather than generated.
+var flagDUIDGenerate DUIDKind
+
+var errNoEUI = errors.New("DUID carries no EUI")
+
+type DUIDResponse struct {
+	Input            string  `json:"input"`
+	InputRaw         string  `json:"input_raw"`
+	Type             string  `json:"type"`
+	HardwareType     *uint16 `json:"hardware_type,omitempty"`
+	LinkLayerAddress string  `json:"link_layer_address,omitempty"`
+	Time             string  `json:"time,omitempty"`
+	EnterpriseNumber *uint32 `json:"enterprise_number,omitempty"`
+	Identifier       string  `json:"identifier,omitempty"`
+	UUID             string  `json:"uuid,omitempty"`
+}
+
+var duidCmd = &cobra.Command{
+	Use:          "duid [duid ...]",
+	Short:        "Parse DHCPv6 DUIDs or generate them from EUIs",
+	Long:         duidResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		lineErrs := newLineErrors(flagOnError, cmd.ErrOrStderr())
+
+		if flagDUIDGenerate == "" {
+			// Parsing emits a JSON per line, there is no column to replace.
+			for _, name := range []string{"time", "field", "field-name", "delimiter", "header", "json-path", "json-set"} {
+				if cmd.Flags().Changed(name) {
+					return fmt.Errorf("--%s requires --generate", name)
+				}
+			}
+			return duidAction(cmd.OutOrStdout(), r, flagEUIFormat, lineErrs)
+		}
+
+		rawTime, err := cmd.Flags().GetString("time")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		generatedAt := time.Now()
+		if rawTime != "" {
+			generatedAt, err = time.Parse(time.RFC3339, rawTime)
+			if err != nil {
+				return berrors.WithStack(err)
+			}
+		}
+
+		table, err := tableOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return duidGenerateAction(cmd.OutOrStdout(), r, flagDUIDGenerate, generatedAt, table, lineErrs)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(duidCmd)
+	duidCmd.Flags().Var(
+		&flagDUIDGenerate,
+		"generate",
+		"generate DUIDs of a given type from EUIs: "+strings.Join(DUIDKindNames(), ", "),
+	)
+	duidCmd.Flags().String("time", "", "time of a generated DUID-LLT in RFC 3339 (default now)")
+	addTableFlags(duidCmd)
+}
+
+func duidAction(w io.Writer, r io.Reader, format EUIFormat, lineErrs *lineErrors) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		line := scanner.Text()
+		duid, err := hwaddr.ParseDUID(line)
+		if err != nil {
+			if err = lineErrs.fail(writer, lineN, 1, line, err); err != nil {
+				return err
+			}
+			continue
+		}
+		lineErrs.ok()
+
+		data, err := json.Marshal(describeDUID(duid, line, format))
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return lineErrs.result()
+}
+
+// describeDUID formats link-layer addresses that are EUIs with format, other
+// ones are always colon separated.
+func describeDUID(duid hwaddr.DUID, line string, format EUIFormat) DUIDResponse {
+	result := DUIDResponse{
+		Input:            duid.String(),
+		InputRaw:         line,
+		Type:             duid.Type().String(),
+		HardwareType:     nil,
+		LinkLayerAddress: "",
+		Time:             "",
+		EnterpriseNumber: nil,
+		Identifier:       "",
+		UUID:             "",
+	}
+
+	if hardwareType, ok := duid.HardwareType(); ok {
+		result.HardwareType = &hardwareType
+	}
+	if eui, ok := duid.EUI(); ok {
+		result.LinkLayerAddress = convertFuncMap[format](eui)
+	} else if addr, ok := duid.LinkLayerAddr(); ok {
+		result.LinkLayerAddress = hwaddr.AsColon(addr)
+	}
+	if ts, ok := duid.Time(); ok {
+		result.Time = ts.Format(time.RFC3339)
+	}
+	if number, ok := duid.EnterpriseNumber(); ok {
+		result.EnterpriseNumber = &number
+	}
+	if identifier, ok := duid.Identifier(); ok {
+		result.Identifier = hwaddr.AsColon(identifier)
+	}
+	if uuid, ok := duid.UUID(); ok {
+		result.UUID = fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+	}
+
+	return result
+}
+
+func duidGenerateAction(
+	w io.Writer, r io.Reader, kind DUIDKind, generatedAt time.Time, table tableOptions, lineErrs *lineErrors,
+) error {
+	return fieldProcessor{
+		table:    table,
+		lineErrs: lineErrs,
+		apply: func(value string) (string, error) {
+			addr, err := hwaddr.ParseAddr(value)
+			if err != nil {
+				return "", err //nolint: wrapcheck // already descriptive
+			}
+
+			var duid hwaddr.DUID
+			switch kind {
+			case DUIDKindLlt:
+				duid, err = hwaddr.NewDUIDLLT(addr, generatedAt)
+			case DUIDKindLl:
+				duid, err = hwaddr.NewDUIDLL(addr)
+			}
+			if err != nil {
+				return "", err //nolint: wrapcheck // already descriptive
+			}
+
+			return duid.String(), nil
+		},
+		annotate: "",
+		quiet:    false,
+	}.run(w, r)
+}
+
+func duidResponseExample() string {
+	hardwareType := hwaddr.HardwareTypeEthernet
+	data, err := json.MarshalIndent(DUIDResponse{
+		Input:            "00:01:00:01:2c:9d:18:31:00:1b:21:0a:0b:0c",
+		InputRaw:         "00-01-00-01-2c-9d-18-31-00-1b-21-0a-0b-0c",
+		Type:             hwaddr.DUIDTypeLLT.String(),
+		HardwareType:     &hardwareType,
+		LinkLayerAddress: "00:1b:21:0a:0b:0c",
+		Time:             "2023-09-20T02:40:49Z",
+		EnterpriseNumber: nil,
+		Identifier:       "",
+		UUID:             "",
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Parse DHCPv6 DUIDs: DUID-LLT, DUID-EN, DUID-LL and DUID-UUID. Valid input is a
+hex string with colons, dashes or without separators, 0x is accepted. Output is
+a JSON per DUID. Example of the output:
+%s
+Keys that do not apply to the type are omitted: hardware_type, link_layer_address
+for DUID-LLT and DUID-LL, time for DUID-LLT, enterprise_number and identifier
+for DUID-EN, uuid for DUID-UUID. EUI-48 (hardware type 1) and EUI-64 (hardware
+type 27) link-layer addresses are formatted with --format.
+
+With --generate ll or --generate llt EUI-48s and EUI-64s are converted into
+DUIDs instead, the table flags select a column of the input to convert. The
+table flags and --time require --generate. The time of a DUID-LLT is taken from
+--time, the current time by default. See 'oui lookup --duid' to lookup the vendor of a DUID`, data)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type ExtractResponse struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Input    string `json:"input"`
+	InputRaw string `json:"input_raw"`
+}
+
+var extractCmd = &cobra.Command{
+	Use:          "extract [text ...]",
+	Short:        "Extract EUIs from arbitrary text",
+	Long:         extractResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		unique, err := cmd.Flags().GetBool("unique")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		return extractAction(cmd.OutOrStdout(), r, flagEUIFormat, unique)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(extractCmd)
+	extractCmd.Flags().Bool("unique", false, "emit only the first occurrence of every EUI")
+}
+
+func extractAction(w io.Writer, r io.Reader, format EUIFormat, unique bool) error {
+	scanner := hwaddr.NewScanner(r)
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+	seen := make(map[string]struct{})
+
+	for scanner.Scan() {
+		match := scanner.Match()
+
+		if unique {
+			key := string(match.Addr)
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+		}
+
+		data, err := json.Marshal(ExtractResponse{
+			Line:     scanner.Line(),
+			Column:   utf8.RuneCountInString(scanner.Text()[:match.Start]) + 1,
+			Input:    convertFunc(match.Addr),
+			InputRaw: match.Text,
+		})
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func extractResponseExample() string {
+	data, err := json.MarshalIndent(ExtractResponse{
+		Line:     3,
+		Column:   42,
+		Input:    "00:aa:11:bb:22:cc",
+		InputRaw: "00aa.11bb.22cc",
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Extract EUIs from arbitrary text like syslog, switch CLI output or JSON. Any
+notation supported by 'eui verify' is recognized as long as the EUI is delimited
+from the surrounding text by non-alphanumeric characters. Output is a JSON per
+EUI found. Example of the output:
+%s
+Line and column count from one, the input key is formatted according to
+--format`, data)
+}
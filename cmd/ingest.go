@@ -0,0 +1,207 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/ingest"
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(ip-neigh, arp-bsd, arp-windows, cisco-mac-table, cisco-arp, junos-ethernet-switching, arista-mac-table).
+type IngestSource string //nolint: recvcheck // generated by a third-party
+
+// ENUM(JSON, CSV).
+type OutputFormat string //nolint: recvcheck // generated by a third-party
+
+var (
+	flagIngestSource IngestSource
+	flagOutputFormat = OutputFormatJSON
+)
+
+var ingestParsers = map[IngestSource]ingest.Parser{
+	IngestSourceIpNeigh:                ingest.IPNeigh,
+	IngestSourceArpBsd:                 ingest.ARPBSD,
+	IngestSourceArpWindows:             ingest.ARPWindows,
+	IngestSourceCiscoMacTable:          ingest.CiscoMACTable,
+	IngestSourceCiscoArp:               ingest.CiscoARP,
+	IngestSourceJunosEthernetSwitching: ingest.JunosEthernetSwitching,
+	IngestSourceAristaMacTable:         ingest.AristaMACTable,
+}
+
+type IngestResponse struct {
+	MAC       string `json:"mac"`
+	IP        string `json:"ip"`
+	VLAN      string `json:"vlan"`
+	Interface string `json:"interface"`
+	Type      string `json:"type"`
+	Vendor    string `json:"vendor"`
+}
+
+var ingestCmd = &cobra.Command{
+	Use:          "ingest --from source [file ...]",
+	Short:        "Parse MAC/ARP tables of hosts and network devices",
+	Long:         ingestResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			return ingestAction(cmd.OutOrStdout(), cmd.InOrStdin(), flagIngestSource, flagOutputFormat, trie)
+		}
+
+		readers := make([]io.Reader, 0, len(args))
+		for _, name := range args {
+			f, ferr := os.Open(name)
+			if ferr != nil {
+				return berrors.WithStack(ferr)
+			}
+			defer f.Close()
+			readers = append(readers, f)
+		}
+
+		return ingestAction(cmd.OutOrStdout(), io.MultiReader(readers...), flagIngestSource, flagOutputFormat, trie)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(ingestCmd)
+	ingestCmd.Flags().Var(
+		&flagIngestSource,
+		"from",
+		"format of the table, permitted options: "+strings.Join(IngestSourceNames(), ", "),
+	)
+	ingestCmd.Flags().Var(
+		&flagOutputFormat,
+		"output",
+		"permitted options: "+strings.Join(OutputFormatNames(), ", ")+" (case insensitive)",
+	)
+	_ = ingestCmd.MarkFlagRequired("from")
+}
+
+func ingestAction(w io.Writer, r io.Reader, source IngestSource, output OutputFormat, trie *registry.Trie) error {
+	entries, err := ingestParsers[source](r)
+	if err != nil {
+		return err //nolint: wrapcheck // already descriptive
+	}
+
+	results := make([]IngestResponse, 0, len(entries))
+	for _, entry := range entries {
+		result := IngestResponse{
+			MAC:       hwaddr.AsColon(entry.MAC),
+			IP:        "",
+			VLAN:      entry.VLAN,
+			Interface: entry.Interface,
+			Type:      entry.Type,
+			Vendor:    vendorName(trie, entry.MAC),
+		}
+		if entry.IP.IsValid() {
+			result.IP = entry.IP.String()
+		}
+		results = append(results, result)
+	}
+
+	switch output {
+	case OutputFormatCSV:
+		return writeIngestCSV(w, results)
+	default:
+		return writeJSONLines(w, results)
+	}
+}
+
+// writeJSONLines writes a JSON per result.
+func writeJSONLines[T any](w io.Writer, results []T) error {
+	writer := bufio.NewWriter(w)
+
+	for _, result := range results {
+		data, err := json.Marshal(result)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func writeIngestCSV(w io.Writer, results []IngestResponse) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"mac", "ip", "vlan", "interface", "type", "vendor"})
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	for _, result := range results {
+		err = writer.Write([]string{
+			result.MAC, result.IP, result.VLAN, result.Interface, result.Type, result.Vendor,
+		})
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func ingestResponseExample() string {
+	data, err := json.MarshalIndent(IngestResponse{
+		MAC:       "00:1b:21:0a:0b:0c",
+		IP:        "10.0.10.23",
+		VLAN:      "10",
+		Interface: "Vlan10",
+		Type:      "dynamic",
+		Vendor:    "Intel Corporate",
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Parse MAC/ARP tables printed by hosts and network devices and enrich entries
+with vendor names. Input is read from files or the standard input. Supported
+sources (--from):
+
+ip-neigh                  'ip neigh' on Linux
+arp-bsd                   'arp -an' on BSD, macOS and Linux (net-tools)
+arp-windows               'arp -a' on Windows
+cisco-mac-table           'show mac address-table' on Cisco IOS and NX-OS
+cisco-arp                 'show ip arp' on Cisco IOS
+junos-ethernet-switching  'show ethernet-switching table' on Junos
+arista-mac-table          'show mac address-table' on Arista EOS
+
+Lines that are not table entries are skipped. Fields a table does not have are
+empty. Output is a JSON per entry or CSV with a header (--output). Example of
+the output:
+%s
+Requires the OUI database, see 'oui update'`, data)
+}
@@ -0,0 +1,164 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(PUBLIC, RANDOM).
+type BDAddrType string //nolint: recvcheck // generated by a third-party
+
+// The zero value means that the input is not treated as Bluetooth addresses.
+var flagBluetooth BDAddrType
+
+type BluetoothResponse struct {
+	AddrType string `json:"addr_type"`
+	Random   bool   `json:"random"`
+}
+
+type InspectResponse struct {
+	Input     string             `json:"input"`
+	InputRaw  string             `json:"input_raw"`
+	Length    int                `json:"length"`
+	Multicast bool               `json:"multicast"`
+	Local     bool               `json:"local"`
+	Bluetooth *BluetoothResponse `json:"bluetooth,omitempty"`
+}
+
+var inspectCmd = &cobra.Command{
+	Use:          "inspect [eui ...]",
+	Short:        "Show properties of an EUI",
+	Long:         inspectResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		return inspectAction(cmd.OutOrStdout(), r, flagEUIFormat, flagBluetooth)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(inspectCmd)
+	addBluetoothFlag(inspectCmd)
+}
+
+func addBluetoothFlag(cmd *cobra.Command) {
+	cmd.Flags().Var(
+		&flagBluetooth,
+		"bluetooth",
+		"treat input as Bluetooth device addresses of a given type: "+
+			strings.Join(BDAddrTypeNames(), ", ")+" (case insensitive)",
+	)
+	cmd.Flags().Lookup("bluetooth").NoOptDefVal = string(BDAddrTypeRANDOM)
+}
+
+func inspectAction(w io.Writer, r io.Reader, format EUIFormat, bluetooth BDAddrType) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		line := scanner.Text()
+		addr, err := hwaddr.ParseHardwareAddr(line)
+		if err != nil {
+			return AtInputPositionError{Position: lineN, Err: err}
+		}
+
+		result := InspectResponse{
+			Input:     convertFunc(addr),
+			InputRaw:  line,
+			Length:    len(addr),
+			Multicast: hwaddr.IsMulticast(addr),
+			Local:     hwaddr.IsLocal(addr),
+			Bluetooth: nil,
+		}
+
+		result.Bluetooth, err = classifyBluetooth(addr, bluetooth)
+		if err != nil {
+			return AtInputPositionError{Position: lineN, Err: err}
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// classifyBluetooth returns nil when addresses are not treated as BD_ADDRs.
+func classifyBluetooth(addr []byte, bluetooth BDAddrType) (*BluetoothResponse, error) {
+	if bluetooth == "" {
+		return nil, nil //nolint: nilnil // absence of the classification is not an error
+	}
+
+	bdAddr, err := hwaddr.EUI48FromBytes(addr)
+	if err != nil {
+		return nil, fmt.Errorf("bluetooth device address must be an EUI-48: %w", err)
+	}
+
+	addrType := hwaddr.ClassifyBluetooth(bdAddr, bluetooth == BDAddrTypeRANDOM)
+
+	return &BluetoothResponse{AddrType: addrType.String(), Random: addrType.IsRandom()}, nil
+}
+
+func inspectResponseExample() string {
+	data, err := json.MarshalIndent(InspectResponse{
+		Input:     "4a:11:22:33:44:55",
+		InputRaw:  "4A11.2233.4455",
+		Length:    hwaddr.EUI48Len,
+		Multicast: false,
+		Local:     true,
+		Bluetooth: &BluetoothResponse{
+			AddrType: hwaddr.BluetoothResolvablePrivate.String(),
+			Random:   true,
+		},
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Show properties of an EUI: length in bytes, I/G (multicast) and U/L (locally
+administered) bits. Output is a JSON. Example of the output:
+%s
+With --bluetooth the input is treated as Bluetooth device addresses (BD_ADDR).
+Public addresses are IEEE assigned. Random addresses are classified by the two
+most significant bits into static random, resolvable private and non-resolvable
+private. --bluetooth without a value assumes random addresses, public addresses
+require --bluetooth=public. The bluetooth key is omitted when --bluetooth is not
+set`, data)
+}
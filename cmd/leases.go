@@ -0,0 +1,199 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/leases"
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(isc-dhcpd, dnsmasq, kea-csv, kea-memfile).
+type LeaseFormat string //nolint: recvcheck // generated by a third-party
+
+var (
+	flagLeaseFormat LeaseFormat
+	flagLeaseOutput = OutputFormatJSON
+)
+
+var leaseParsers = map[LeaseFormat]leases.Parser{
+	LeaseFormatIscDhcpd:   leases.ISCDhcpd,
+	LeaseFormatDnsmasq:    leases.Dnsmasq,
+	LeaseFormatKeaCsv:     leases.KeaCSV,
+	LeaseFormatKeaMemfile: leases.KeaMemfile,
+}
+
+type LeaseResponse struct {
+	IP       string `json:"ip"`
+	MAC      string `json:"mac"`
+	Hostname string `json:"hostname"`
+	ClientID string `json:"client_id"`
+	Expiry   string `json:"expiry"`
+	State    string `json:"state"`
+	Vendor   string `json:"vendor"`
+}
+
+var leasesCmd = &cobra.Command{
+	Use:          "leases --format format [file ...]",
+	Short:        "Parse lease databases of DHCP servers",
+	Long:         leasesResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			list, perr := leaseParsers[flagLeaseFormat](cmd.InOrStdin())
+			if perr != nil {
+				return perr //nolint: wrapcheck // already descriptive
+			}
+			return leasesAction(cmd.OutOrStdout(), list, flagLeaseOutput, trie)
+		}
+
+		// Files are parsed one by one so that the CSV header of every Kea file
+		// is honored.
+		var list []leases.Lease
+		for _, name := range args {
+			fileLeases, ferr := readLeasesFile(name, flagLeaseFormat)
+			if ferr != nil {
+				return ferr
+			}
+			list = append(list, fileLeases...)
+		}
+
+		return leasesAction(cmd.OutOrStdout(), list, flagLeaseOutput, trie)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(leasesCmd)
+	leasesCmd.Flags().Var(
+		&flagLeaseFormat,
+		"format",
+		"format of the lease database, permitted options: "+strings.Join(LeaseFormatNames(), ", "),
+	)
+	leasesCmd.Flags().Var(
+		&flagLeaseOutput,
+		"output",
+		"permitted options: "+strings.Join(OutputFormatNames(), ", ")+" (case insensitive)",
+	)
+	_ = leasesCmd.MarkFlagRequired("format")
+}
+
+func readLeasesFile(name string, format LeaseFormat) ([]leases.Lease, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, berrors.WithStack(err)
+	}
+	defer f.Close()
+
+	return leaseParsers[format](f) //nolint: wrapcheck // already descriptive
+}
+
+func leasesAction(w io.Writer, list []leases.Lease, output OutputFormat, trie *registry.Trie) error {
+	results := make([]LeaseResponse, 0, len(list))
+	for _, lease := range list {
+		result := LeaseResponse{
+			IP:       lease.IP.String(),
+			MAC:      "",
+			Hostname: lease.Hostname,
+			ClientID: "",
+			Expiry:   "",
+			State:    lease.State,
+			Vendor:   "",
+		}
+		if lease.PrefixLen != 0 {
+			result.IP += "/" + strconv.Itoa(lease.PrefixLen)
+		}
+		if lease.MAC != nil {
+			result.MAC = hwaddr.AsColon(lease.MAC)
+			result.Vendor = vendorName(trie, lease.MAC)
+		}
+		if lease.ClientID != nil {
+			result.ClientID = hwaddr.AsColon(lease.ClientID)
+		}
+		if !lease.Expiry.IsZero() {
+			result.Expiry = lease.Expiry.Format(time.RFC3339)
+		}
+		results = append(results, result)
+	}
+
+	switch output {
+	case OutputFormatCSV:
+		return writeLeasesCSV(w, results)
+	default:
+		return writeJSONLines(w, results)
+	}
+}
+
+func writeLeasesCSV(w io.Writer, results []LeaseResponse) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"ip", "mac", "hostname", "client_id", "expiry", "state", "vendor"})
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	for _, result := range results {
+		err = writer.Write([]string{
+			result.IP, result.MAC, result.Hostname, result.ClientID, result.Expiry, result.State, result.Vendor,
+		})
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func leasesResponseExample() string {
+	data, err := json.MarshalIndent(LeaseResponse{
+		IP:       "192.168.1.10",
+		MAC:      "00:1b:21:0a:0b:0c",
+		Hostname: "laptop",
+		ClientID: "01:00:1b:21:0a:0b:0c",
+		Expiry:   "2023-11-16T22:00:00Z",
+		State:    "active",
+		Vendor:   "Intel Corporate",
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Parse the lease database of a DHCP server and enrich leases with vendor names.
+Input is read from files or the standard input. Supported formats (--format):
+
+isc-dhcpd    dhcpd.leases of ISC dhcpd, DHCPv4 and DHCPv6
+dnsmasq      dnsmasq.leases
+kea-memfile  kea-leases4.csv and kea-leases6.csv of the Kea memfile backend
+kea-csv      leases exported from Kea, e.g. by 'kea-admin lease-dump'
+
+Lease files keep a history of updates, only the latest entry of an address is
+reported except for kea-csv where every row is a lease. The MAC of a client is
+taken from the DHCPv4 client identifier or the DHCPv6 DUID (DUID-LLT and
+DUID-LL) when the database does not record it. Leases that never expire have an
+empty expiry. Output is a JSON per lease or CSV with a header (--output).
+Example of the output:
+%s
+Requires the OUI database, see 'oui update'`, data)
+}
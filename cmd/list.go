@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ttl256/euivator/internal/registry"
+)
+
+var listCmd = &cobra.Command{
+	Use:          "list [hex_prefix ...]",
+	Short:        "List all assignments below a hex prefix",
+	Long:         listResponseExample(),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		return lookupAction(
+			cmd.OutOrStdout(), r, lookupOptions{wwn: false, duid: false, bluetooth: "", subtree: true, allMatches: false},
+			tableOptions{}, newLineErrors(flagOnError, cmd.ErrOrStderr()),
+		)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(listCmd)
+}
+
+func listResponseExample() string {
+	data, err := json.MarshalIndent(RecordResponse{
+		Input:    "70B3D57E",
+		InputRaw: "70:b3:d5:7e",
+		Records: []registry.Record{
+			{
+				Assignment: "70B3D57ED",
+				Registry:   registry.NameMAS,
+				OrgName:    "The Things Industries B.V.",
+				OrgAddress: "Amsterdam NL",
+			},
+		},
+		Matches:        nil,
+		Counts:         map[registry.Name]int{registry.NameMAS: 1},
+		Bluetooth:      nil,
+		Classification: nil,
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`List every assignment of the OUI database below a hex prefix, e.g. all MA-S
+blocks carved out of 8C1F64, when a prefix is too short for 'oui lookup' to
+match anything. Valid input is any hex string with separators from [-:.]. The
+assignment of the prefix itself is included. Output is a JSON per prefix with
+records sorted by assignment and the number of them in every registry:
+%s
+The same as 'oui lookup --subtree'.`, data)
+}
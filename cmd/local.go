@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/netinfo"
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type LocalResponse struct {
+	Interface string   `json:"interface"`
+	MAC       string   `json:"mac"`
+	Vendor    string   `json:"vendor"`
+	Local     bool     `json:"local"`
+	Multicast bool     `json:"multicast"`
+	SLAAC     []string `json:"slaac"`
+}
+
+var localCmd = &cobra.Command{
+	Use:          "local",
+	Short:        "List hardware addresses of local interfaces",
+	Long:         localResponseExample(),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		root, err := cmd.Flags().GetString("root")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		return localAction(cmd.OutOrStdout(), root, flagEUIFormat, trie)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(localCmd)
+	localCmd.Flags().String("root", "/", "root of the filesystem holding sysfs and procfs")
+}
+
+func localAction(w io.Writer, root string, format EUIFormat, trie *registry.Trie) error {
+	ifaces, err := netinfo.Interfaces(root)
+	if err != nil {
+		return err //nolint: wrapcheck // already descriptive
+	}
+
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+
+	for _, iface := range ifaces {
+		slaac := make([]string, 0, len(iface.Prefixes))
+		for _, addr := range iface.SLAAC() {
+			slaac = append(slaac, addr.String())
+		}
+
+		data, merr := json.Marshal(LocalResponse{
+			Interface: iface.Name,
+			MAC:       convertFunc(iface.MAC),
+			Vendor:    vendorName(trie, iface.MAC),
+			Local:     hwaddr.IsLocal(iface.MAC),
+			Multicast: hwaddr.IsMulticast(iface.MAC),
+			SLAAC:     slaac,
+		})
+		if merr != nil {
+			return berrors.WithStack(merr)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func localResponseExample() string {
+	data, err := json.MarshalIndent(LocalResponse{
+		Interface: "eth0",
+		MAC:       "00:1b:21:0a:0b:0d",
+		Vendor:    "Intel Corporate",
+		Local:     false,
+		Multicast: false,
+		SLAAC:     []string{"fe80::21b:21ff:fe0a:b0d", "2001:db8:1:0:21b:21ff:fe0a:b0d"},
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`List interfaces of the local Linux host that have an EUI-48/EUI-64 hardware
+address, read from /sys/class/net. Each record carries the vendor, U/L and I/G
+bits and the addresses SLAAC derives from the hardware address for every /64
+IPv6 prefix of the interface (/proc/net/if_inet6), link-local included. --root
+points at another filesystem tree. When sysfs is not available the interfaces
+are enumerated by the operating system. Example of the output:
+%s
+Requires the OUI database, see 'oui update'`, data)
+}
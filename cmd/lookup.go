@@ -11,6 +11,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -23,10 +25,25 @@ import (
 	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
+var errNoOrganization = errors.New("no assignments of a matching organization")
+
+type lookupOptions struct {
+	wwn       bool
+	duid      bool
+	bluetooth BDAddrType
+	subtree   bool
+	// allMatches adds records of every matching prefix, not just the longest.
+	allMatches bool
+}
+
 type RecordResponse struct {
-	Input    string            `json:"input"`
-	InputRaw string            `json:"input_raw"`
-	Records  []registry.Record `json:"records"`
+	Input          string                  `json:"input"`
+	InputRaw       string                  `json:"input_raw"`
+	Records        []registry.Record       `json:"records"`
+	Matches        []registry.Match        `json:"matches,omitempty"`
+	Counts         map[registry.Name]int   `json:"counts,omitempty"`
+	Bluetooth      *BluetoothResponse      `json:"bluetooth,omitempty"`
+	Classification *ClassificationResponse `json:"classification,omitempty"`
 }
 
 var lookupCmd = &cobra.Command{
@@ -42,53 +59,100 @@ var lookupCmd = &cobra.Command{
 			r = cmd.InOrStdin()
 		}
 
-		return lookupAction(cmd.OutOrStdout(), r)
+		wwn, err := cmd.Flags().GetBool("wwn")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		duid, err := cmd.Flags().GetBool("duid")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		subtree, err := cmd.Flags().GetBool("subtree")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		allMatches, err := cmd.Flags().GetBool("all-matches")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		table, err := tableOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return lookupAction(
+			cmd.OutOrStdout(), r,
+			lookupOptions{wwn: wwn, duid: duid, bluetooth: flagBluetooth, subtree: subtree, allMatches: allMatches},
+			table, newLineErrors(flagOnError, cmd.ErrOrStderr()),
+		)
 	},
 }
 
 func init() {
 	ouiCmd.AddCommand(lookupCmd)
+	lookupCmd.Flags().Bool("wwn", false, "treat input as Fibre Channel WWNs and lookup the embedded OUI")
+	lookupCmd.Flags().Bool("duid", false, "treat input as DHCPv6 DUIDs and lookup the link-layer address")
+	lookupCmd.Flags().Bool("subtree", false, "return all assignments below the prefix instead of the longest match")
+	lookupCmd.Flags().Bool("all-matches", false, "also return assignments of every shorter matching prefix")
+	addBluetoothFlag(lookupCmd)
+	lookupCmd.MarkFlagsMutuallyExclusive("wwn", "duid", "bluetooth")
+	lookupCmd.MarkFlagsMutuallyExclusive("subtree", "wwn", "duid", "bluetooth")
+	lookupCmd.MarkFlagsMutuallyExclusive("subtree", "all-matches")
+	addTableFlags(lookupCmd)
+	// Tables get a single column of names, matches have nowhere to go.
+	lookupCmd.MarkFlagsMutuallyExclusive("all-matches", "field", "field-name", "json-path")
 }
 
-func lookupAction(w io.Writer, r io.Reader) error {
-	lookupFile := filepath.Join(viper.GetString("cachedir"), LookupFile)
-	f, err := os.Open(lookupFile)
+/*
+lookupAction emits a JSON per line. When a column is selected rows are passed
+through with names of organizations appended as a new column instead.
+*/
+func lookupAction(w io.Writer, r io.Reader, opts lookupOptions, table tableOptions, lineErrs *lineErrors) error {
+	trie, err := loadTrie(viper.GetString("cachedir"))
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return fmt.Errorf(
-				"unable to open %q. try running '%s oui update' to prepare required cache",
-				lookupFile,
-				appName,
-			)
-		}
-		return berrors.WithStack(err)
+		return err
 	}
-	defer f.Close()
 
-	trie := registry.NewTrie()
-	err = trie.DecodeGOB(f)
-	if err != nil {
-		return fmt.Errorf("loading lookup database: %w", err)
+	if table.enabled() {
+		return fieldProcessor{
+			table:    table,
+			lineErrs: lineErrs,
+			apply: func(value string) (string, error) {
+				result, rerr := resolveLookup(trie, value, opts)
+				if rerr != nil {
+					return "", rerr
+				}
+				return orgNames(result.Records), nil
+			},
+			annotate: "org_name",
+			quiet:    false,
+		}.run(w, r)
 	}
 
 	scanner := bufio.NewScanner(r)
 	writer := bufio.NewWriter(w)
 
-	var prefix string
-	var data []byte
+	var (
+		result RecordResponse
+		data   []byte
+		lineN  int
+	)
 	for scanner.Scan() {
+		lineN++
 		line := scanner.Text()
-		prefix, err = stringToHexPrefix(line)
+		result, err = resolveLookup(trie, line, opts)
 		if err != nil {
-			return err
+			if err = lineErrs.fail(writer, lineN, 1, line, err); err != nil {
+				return err
+			}
+			continue
 		}
+		lineErrs.ok()
 
-		records := trie.LongestPrefixMatch(prefix)
-		result := RecordResponse{
-			Input:    prefix,
-			InputRaw: line,
-			Records:  records,
-		}
 		data, err = json.Marshal(result)
 		if err != nil {
 			return berrors.WithStack(err)
@@ -110,7 +174,205 @@ func lookupAction(w io.Writer, r io.Reader) error {
 		return berrors.WithStack(err)
 	}
 
-	return nil
+	return lineErrs.result()
+}
+
+func loadTrie(dir string) (*registry.Trie, error) {
+	lookupFile := filepath.Join(dir, LookupFile)
+	f, err := os.Open(lookupFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf(
+				"unable to open %q. try running '%s oui update' to prepare required cache",
+				lookupFile,
+				appName,
+			)
+		}
+		return nil, berrors.WithStack(err)
+	}
+	defer f.Close()
+
+	trie := registry.NewTrie()
+	err = trie.DecodeGOB(f)
+	if err != nil {
+		return nil, fmt.Errorf("loading lookup database: %w", err)
+	}
+
+	return trie, nil
+}
+
+// orgFromFlags compiles the case insensitive regular expression of --org.
+func orgFromFlags(cmd *cobra.Command) (*regexp.Regexp, error) {
+	org, err := cmd.Flags().GetString("org")
+	if err != nil {
+		return nil, berrors.WithStack(err)
+	}
+
+	re, err := regexp.Compile("(?i)" + org)
+	if err != nil {
+		return nil, fmt.Errorf("parsing --org: %w", err)
+	}
+
+	return re, nil
+}
+
+/*
+organizationRecords returns MA-L, MA-M and MA-S assignments of organizations
+whose names match org sorted by assignment. CIDs are left out as they are not
+used in universally administered MACs.
+*/
+func organizationRecords(trie *registry.Trie, org *regexp.Regexp) ([]registry.Record, error) {
+	var records []registry.Record
+	for _, record := range trie.Traverse() {
+		if record.Registry != registry.NameCID && org.MatchString(record.OrgName) {
+			records = append(records, record)
+		}
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %q", errNoOrganization, strings.TrimPrefix(org.String(), "(?i)"))
+	}
+
+	slices.SortFunc(records, func(a, b registry.Record) int {
+		return strings.Compare(a.Assignment, b.Assignment)
+	})
+
+	return records, nil
+}
+
+func resolveLookup(trie *registry.Trie, line string, opts lookupOptions) (RecordResponse, error) {
+	result := RecordResponse{
+		Input:          "",
+		InputRaw:       line,
+		Records:        []registry.Record{},
+		Matches:        nil,
+		Counts:         nil,
+		Bluetooth:      nil,
+		Classification: nil,
+	}
+
+	if opts.bluetooth != "" {
+		addr, err := hwaddr.ParseAddr(line)
+		if err != nil {
+			return RecordResponse{}, err //nolint: wrapcheck // already descriptive
+		}
+		result.Input = hexPrefix(addr)
+		result.Bluetooth, err = classifyBluetooth(addr, opts.bluetooth)
+		if err != nil {
+			return RecordResponse{}, err
+		}
+		// The upper bits of random addresses are not an OUI, any match is bogus.
+		if result.Bluetooth.Random {
+			return result, nil
+		}
+		result.match(trie, result.Input, opts)
+
+		return result, nil
+	}
+
+	// A prefix length asks for every assignment overlapping the bit prefix.
+	if !opts.wwn && !opts.duid && strings.Contains(line, "/") {
+		if opts.allMatches {
+			return RecordResponse{}, fmt.Errorf("--all-matches takes no prefix length, got %q", line)
+		}
+		block, err := hwaddr.ParsePrefix(strings.TrimPrefix(strings.TrimPrefix(line, "0x"), "0X"))
+		if err != nil {
+			return RecordResponse{}, err //nolint: wrapcheck // already descriptive
+		}
+		result.Input = bitPrefix(block)
+		result.Records = append(result.Records, trie.Overlapping(block)...)
+		result.Counts = registryCounts(result.Records)
+
+		return result, nil
+	}
+
+	prefix, err := lookupKey(line, opts)
+	if err != nil {
+		return RecordResponse{}, err
+	}
+	result.Input = prefix
+	if opts.subtree {
+		// Nothing is assigned below the longest assignment, an MA-S.
+		if maxDigits := registry.NameMAS.BitLength() / hwaddr.NibbleBits; len(prefix) > maxDigits {
+			return RecordResponse{}, fmt.Errorf(
+				"%w: subtree prefixes are up to %d hex digits, got %d in %q",
+				hwaddr.ErrInputTooLong, maxDigits, len(prefix), line,
+			)
+		}
+		result.Records = append(result.Records, trie.Subtree(prefix)...)
+		result.Counts = registryCounts(result.Records)
+	} else {
+		result.match(trie, prefix, opts)
+	}
+
+	// Well-known addresses are only meaningful for complete EUI-48s.
+	if !opts.wwn && len(prefix) == hwaddr.EUI48HexLen {
+		addr, _ := hwaddr.ParseAddr(prefix)
+		result.Classification = classify(addr)
+	}
+
+	return result, nil
+}
+
+// match fills records of the longest match of prefix and with allMatches the
+// chain of all the matching prefixes.
+func (r *RecordResponse) match(trie *registry.Trie, prefix string, opts lookupOptions) {
+	r.Records = trie.LongestPrefixMatch(prefix)
+	if opts.allMatches {
+		r.Matches = trie.AllMatches(prefix)
+	}
+}
+
+/*
+bitPrefix spells a block as hex digits covering its length followed by the
+length in bits, e.g. 001B2/20 or 001B20/22.
+*/
+func bitPrefix(block hwaddr.Block) string {
+	digits := (block.Bits + hwaddr.NibbleBits - 1) / hwaddr.NibbleBits
+	return fmt.Sprintf("%s/%d", strings.ToUpper(hwaddr.AsPlain(block.Prefix[:]))[:digits], block.Bits)
+}
+
+// registryCounts returns the number of records of every registry.
+func registryCounts(records []registry.Record) map[registry.Name]int {
+	counts := make(map[registry.Name]int)
+	for _, record := range records {
+		counts[record.Registry]++
+	}
+	return counts
+}
+
+// lookupKey converts a line of input into a key of [registry.Trie].
+func lookupKey(line string, opts lookupOptions) (string, error) {
+	if opts.wwn {
+		wwn, err := hwaddr.ParseWWN(line)
+		if err != nil {
+			return "", err //nolint: wrapcheck // already descriptive
+		}
+		oui := wwn.OUI()
+		return hexPrefix(oui[:]), nil
+	}
+
+	if opts.duid {
+		duid, err := hwaddr.ParseDUID(line)
+		if err != nil {
+			return "", err //nolint: wrapcheck // already descriptive
+		}
+		eui, ok := duid.EUI()
+		if !ok {
+			return "", fmt.Errorf("%w: %s", errNoEUI, duid.Type())
+		}
+		return hexPrefix(eui), nil
+	}
+
+	// IPoIB addresses are looked up by the GUID of the port.
+	if addr, err := hwaddr.ParseHardwareAddr(line); err == nil {
+		if ipoib, ok := addr.IPoIB(); ok {
+			guid := ipoib.GUID()
+			return hexPrefix(guid[:]), nil
+		}
+	}
+
+	// GUIDs are often printed with a leading 0x.
+	return stringToHexPrefix(strings.TrimPrefix(strings.TrimPrefix(line, "0x"), "0X"))
 }
 
 func stringToHexPrefix(s string) (string, error) {
@@ -161,6 +423,10 @@ func recordsResponseExample() string {
 				OrgAddress: "No.388 Ning Qiao Road,Jin Qiao Pudong Shanghai Shanghai   CN 201206",
 			},
 		},
+		Matches:        nil,
+		Counts:         nil,
+		Bluetooth:      nil,
+		Classification: nil,
 	}, "", "  ")
 	if err != nil {
 		panic(err)
@@ -168,5 +434,40 @@ func recordsResponseExample() string {
 	return fmt.Sprintf(`Lookup an EUI/hex prefix in the OUI database. Valid input is any hex string
 with separators from [-:.]. Output is a JSON. Example of the output:
 %s
-The records key contains a list of zero-length when allocation is not found`, data)
+The records key contains a list of zero-length when allocation is not found.
+
+20-byte IP over InfiniBand addresses are looked up by the port GUID, InfiniBand
+GUIDs may be prefixed with 0x.
+
+With --bluetooth the input is treated as Bluetooth device addresses. Random
+addresses are not IEEE assigned so they are not looked up, the records key is
+empty and the bluetooth key tells the kind of the address. See 'eui inspect'.
+
+Complete EUI-48s are also matched against a table of well-known addresses, the
+classification key is present on a match. See 'eui classify'.
+
+A prefix length after the input, e.g. 00:1b:21:00:00:00/20, looks up every
+assignment overlapping the bit prefix: the ones containing it and the ones
+inside it. The length may fall between hex digits. The counts key holds the
+number of them in every registry.
+
+With --all-matches the matches key lists assignments of every matching prefix
+from the shortest to the longest, e.g. the MA-L of the IEEE Registration
+Authority followed by the MA-S carved out of it. Records of the longest prefix
+are marked with "most_specific": true, they are the owner rather than the
+Registration Authority. The records key is unchanged. Tables (--field,
+--field-name, --json-path) and prefixes with a length, which already list every
+overlapping assignment, are rejected.
+
+With --subtree the records key contains every assignment below the prefix
+rather than the longest match, sorted by assignment, and the counts key holds
+the number of them in every registry. Prefixes are up to 9 hex digits, the
+length of an MA-S. See 'oui list'.
+
+With --wwn the input is a Fibre Channel WWN (NAA 1, 2, 5 or 6) and the lookup
+is performed on the embedded OUI rather than on the leading NAA nibble.
+
+With --duid the input is a DHCPv6 DUID and the lookup is performed on the
+link-layer address of a DUID-LLT or a DUID-LL. DUID-EN and DUID-UUID carry no
+address and are rejected. See 'eui duid'`, data)
 }
@@ -0,0 +1,281 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(MSB, LSB).
+type ByteOrder string //nolint: recvcheck // generated by a third-party
+
+var (
+	flagLoRaWANInputOrder  = ByteOrderMSB
+	flagLoRaWANOutputOrder = ByteOrderMSB
+)
+
+var errNotRegistered = errors.New("prefix is not registered")
+
+var lorawanCmd = &cobra.Command{
+	Use:   "lorawan",
+	Short: "Work with LoRaWAN DevEUIs and JoinEUIs",
+	Long: `Work with LoRaWAN DevEUIs and JoinEUIs. Both identifiers are EUI-64 but
+network servers print them either most significant byte first (MSB) or least
+significant byte first (LSB). Use --input-order and --output-order to convert
+between the two.`,
+}
+
+var lorawanNormalizeCmd = &cobra.Command{
+	Use:          "normalize [eui64 ...]",
+	Short:        "Convert a DevEUI/JoinEUI between byte orders and formats",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		return lorawanNormalizeAction(
+			cmd.OutOrStdout(), r, flagEUIFormat, flagLoRaWANInputOrder, flagLoRaWANOutputOrder,
+		)
+	},
+}
+
+var lorawanVerifyCmd = &cobra.Command{
+	Use:   "verify [eui64 ...]",
+	Short: "Verify that a DevEUI/JoinEUI belongs to a registered OUI/MA-M/MA-S",
+	Long: `Verify that a DevEUI/JoinEUI belongs to a registered OUI/MA-M/MA-S.
+Requires the OUI database, see 'oui update'.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		return lorawanVerifyAction(r, trie, flagLoRaWANInputOrder)
+	},
+}
+
+var lorawanAllocateCmd = &cobra.Command{
+	Use:   "allocate",
+	Short: "Allocate sequential DevEUIs from an owned block",
+	Long: `Allocate sequential DevEUIs from an owned block. The block is a hex prefix of
+an assignment: MA-L (6 hex digits), MA-M (7 hex digits) or MA-S/OUI-36 (9 hex
+digits). Addresses are numbered from zero within the block.`,
+	Args:         cobra.ExactArgs(0),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		blockRaw, err := cmd.Flags().GetString("block")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		start, err := cmd.Flags().GetUint64("start")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		count, err := cmd.Flags().GetUint64("count")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		block, err := parseAssignmentBlock(blockRaw)
+		if err != nil {
+			return err
+		}
+
+		return lorawanAllocateAction(cmd.OutOrStdout(), block, start, count, flagEUIFormat, flagLoRaWANOutputOrder)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(lorawanCmd)
+	lorawanCmd.AddCommand(lorawanNormalizeCmd, lorawanVerifyCmd, lorawanAllocateCmd)
+
+	lorawanCmd.PersistentFlags().Var(
+		&flagLoRaWANInputOrder,
+		"input-order",
+		"byte order of the input: "+strings.Join(ByteOrderNames(), ", ")+" (case insensitive)",
+	)
+	lorawanCmd.PersistentFlags().Var(
+		&flagLoRaWANOutputOrder,
+		"output-order",
+		"byte order of the output: "+strings.Join(ByteOrderNames(), ", ")+" (case insensitive)",
+	)
+
+	lorawanAllocateCmd.Flags().String("block", "", "hex prefix of an owned assignment, e.g. 70B3D57ED")
+	lorawanAllocateCmd.Flags().Uint64("start", 0, "index of the first address within the block")
+	lorawanAllocateCmd.Flags().Uint64("count", 1, "number of addresses to allocate")
+	_ = lorawanAllocateCmd.MarkFlagRequired("block")
+}
+
+// lorawanEUI parses an address as a DevEUI/JoinEUI and returns it MSB first.
+func lorawanEUI(s string, order ByteOrder) (hwaddr.EUI64, error) {
+	addr, err := hwaddr.ParseAddr(s)
+	if err != nil {
+		return hwaddr.EUI64{}, err
+	}
+
+	eui, err := hwaddr.EUI64FromBytes(addr)
+	if err != nil {
+		return hwaddr.EUI64{}, err
+	}
+
+	if order == ByteOrderLSB {
+		return eui.Reversed(), nil
+	}
+
+	return eui, nil
+}
+
+func formatLoRaWANEUI(eui hwaddr.EUI64, format EUIFormat, order ByteOrder) string {
+	if order == ByteOrderLSB {
+		eui = eui.Reversed()
+	}
+
+	return convertFuncMap[format](eui[:])
+}
+
+func lorawanNormalizeAction(w io.Writer, r io.Reader, format EUIFormat, in, out ByteOrder) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		eui, err := lorawanEUI(scanner.Text(), in)
+		if err != nil {
+			return AtInputPositionError{Position: lineN, Err: err}
+		}
+
+		_, err = writer.WriteString(formatLoRaWANEUI(eui, format, out) + "\n")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func lorawanVerifyAction(r io.Reader, trie *registry.Trie, order ByteOrder) error {
+	scanner := bufio.NewScanner(r)
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		eui, err := lorawanEUI(scanner.Text(), order)
+		if err != nil {
+			return AtInputPositionError{Position: lineN, Err: err}
+		}
+
+		if len(trie.LongestPrefixMatch(hexPrefix(eui[:]))) > 0 {
+			continue
+		}
+
+		err = fmt.Errorf("%w: %s", errNotRegistered, eui)
+		if reversed := eui.Reversed(); len(trie.LongestPrefixMatch(hexPrefix(reversed[:]))) > 0 {
+			err = fmt.Errorf("%w (registered when read in reverse byte order)", err)
+		}
+
+		return AtInputPositionError{Position: lineN, Err: err}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func lorawanAllocateAction(
+	w io.Writer, block hwaddr.Block, start, count uint64, format EUIFormat, order ByteOrder,
+) error {
+	writer := bufio.NewWriter(w)
+
+	if count > 0 {
+		// Validate the whole range upfront to not emit a partial allocation.
+		last := start + count - 1
+		if _, err := block.Nth(last); err != nil || last < start {
+			return fmt.Errorf(
+				"block %s holds %d addresses, unable to allocate %d starting at %d: %w",
+				block, block.Size(), count, start, hwaddr.ErrBlockOutOfRange,
+			)
+		}
+	}
+
+	for i := range count {
+		eui, _ := block.Nth(start + i)
+		_, err := writer.WriteString(formatLoRaWANEUI(eui, format, order) + "\n")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// parseAssignmentBlock parses a hex prefix of a registry assignment.
+func parseAssignmentBlock(s string) (hwaddr.Block, error) {
+	const (
+		bitsMAL = 24
+		bitsMAM = 28
+		bitsMAS = 36
+	)
+
+	block, err := hwaddr.ParseBlock(s)
+	if err != nil {
+		return hwaddr.Block{}, err //nolint: wrapcheck // already descriptive
+	}
+
+	switch block.Bits {
+	case bitsMAL, bitsMAM, bitsMAS:
+		return block, nil
+	default:
+		return hwaddr.Block{}, fmt.Errorf(
+			"%w %q: expected a prefix of %d, %d or %d bits, got %d",
+			hwaddr.ErrInvalidBlock, s, bitsMAL, bitsMAM, bitsMAS, block.Bits,
+		)
+	}
+}
+
+// hexPrefix converts an address into a key of [registry.Trie].
+func hexPrefix(addr []byte) string {
+	return strings.ToUpper(hwaddr.AsPlain(addr))
+}
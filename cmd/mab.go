@@ -0,0 +1,262 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/mab"
+	"github.com/ttl256/euivator/internal/registry"
+)
+
+// ENUM(authorized-macs, users).
+type MABFile string //nolint: recvcheck // generated by a third-party
+
+var flagMABFile = MABFileAuthorizedMacs
+
+var mabCmd = &cobra.Command{
+	Use:   "mab",
+	Short: "RADIUS MAC Authentication Bypass helpers",
+	Long: `Helpers for MAC Authentication Bypass (MAB) where a switch or an access point
+sends the MAC of a client as the RADIUS Calling-Station-Id or User-Name. Vendors
+spell MACs differently: 001B210A0B0C, 00-1B-21-0A-0B-0C, 001b.210a.0b0c. Use
+--format and --upper to match the spelling the RADIUS server sees, e.g.
+--format dash --upper for the IETF style produced by the
+rewrite_calling_station_id policy of FreeRADIUS.
+
+Entries of 'users' and 'vendor' are in the users file format of the FreeRADIUS
+files module (rlm_files). Reply attributes are given with --reply verbatim, e.g.
+--reply 'Tunnel-Private-Group-Id = 20'.`,
+}
+
+var mabNormalizeCmd = &cobra.Command{
+	Use:          "normalize [mac ...]",
+	Short:        "Convert MACs from MAB attributes to a single spelling",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		opts, err := mabOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		table, err := tableOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return mabNormalizeAction(cmd.OutOrStdout(), r, opts, table, newLineErrors(flagOnError, cmd.ErrOrStderr()))
+	},
+}
+
+var mabUsersCmd = &cobra.Command{
+	Use:   "users [mac ...]",
+	Short: "Generate FreeRADIUS entries authorizing MACs",
+	Long: `Generate FreeRADIUS entries authorizing MACs. Kinds of entries (--file):
+
+authorized-macs  the MAC alone, for a files module keyed on Calling-Station-Id
+                 as in the FreeRADIUS MAC authentication guide:
+                 00-1B-21-0A-0B-0C
+users            the MAC as both User-Name and password as sent by switches
+                 doing MAB:
+                 001b210a0b0c Cleartext-Password := "001b210a0b0c"
+
+Reply attributes of --reply follow every entry.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		opts, err := mabOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return mabUsersAction(cmd.OutOrStdout(), r, flagMABFile, opts, newLineErrors(flagOnError, cmd.ErrOrStderr()))
+	},
+}
+
+var mabVendorCmd = &cobra.Command{
+	Use:   "vendor --org regexp",
+	Short: "Generate FreeRADIUS policies authorizing all MACs of a vendor",
+	Long: `Generate FreeRADIUS policies authorizing all MACs of a vendor. Every MA-L, MA-M
+and MA-S assignment of organizations whose name matches --org (a case
+insensitive regular expression) becomes a DEFAULT entry matching the prefix:
+
+# Intel Corporate, MA-L 001B21
+DEFAULT Calling-Station-Id =~ "^00-1B-21", Auth-Type := Accept
+
+The attribute is chosen with --attribute, reply attributes of --reply follow
+every entry. CIDs are left out as they are not used in universally administered
+MACs. Requires the OUI database, see 'oui update'.`,
+	Args:         cobra.ExactArgs(0),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		org, err := orgFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		attribute, err := cmd.Flags().GetString("attribute")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		opts, err := mabOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		return mabVendorAction(cmd.OutOrStdout(), trie, org, attribute, opts)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(mabCmd)
+	mabCmd.AddCommand(mabNormalizeCmd, mabUsersCmd, mabVendorCmd)
+
+	mabCmd.PersistentFlags().Bool("upper", false, "spell hex digits of MACs in upper case")
+	addTableFlags(mabNormalizeCmd)
+
+	for _, cmd := range []*cobra.Command{mabUsersCmd, mabVendorCmd} {
+		cmd.Flags().StringArray("reply", nil, "reply attribute of every entry, may be repeated")
+	}
+	mabUsersCmd.Flags().Var(
+		&flagMABFile,
+		"file",
+		"kind of entries, permitted options: "+strings.Join(MABFileNames(), ", "),
+	)
+	mabVendorCmd.Flags().String("org", "", "regular expression matching names of organizations")
+	mabVendorCmd.Flags().String("attribute", "Calling-Station-Id", "RADIUS attribute holding the MAC")
+	_ = mabVendorCmd.MarkFlagRequired("org")
+}
+
+func mabOptionsFromFlags(cmd *cobra.Command) (mab.Options, error) {
+	upper, err := cmd.Flags().GetBool("upper")
+	if err != nil {
+		return mab.Options{}, berrors.WithStack(err)
+	}
+
+	var reply []string
+	if cmd.Flags().Lookup("reply") != nil {
+		reply, err = cmd.Flags().GetStringArray("reply")
+		if err != nil {
+			return mab.Options{}, berrors.WithStack(err)
+		}
+	}
+
+	return mab.Options{Format: convertFuncMap[flagEUIFormat], Upper: upper, Reply: reply}, nil
+}
+
+func mabNormalizeAction(w io.Writer, r io.Reader, opts mab.Options, table tableOptions, lineErrs *lineErrors) error {
+	return fieldProcessor{
+		table:    table,
+		lineErrs: lineErrs,
+		apply: func(value string) (string, error) {
+			addr, err := mab.Parse(value)
+			if err != nil {
+				return "", err //nolint: wrapcheck // already descriptive
+			}
+			return opts.Spell(addr), nil
+		},
+		annotate: "",
+		quiet:    false,
+	}.run(w, r)
+}
+
+func mabUsersAction(w io.Writer, r io.Reader, file MABFile, opts mab.Options, lineErrs *lineErrors) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		line := scanner.Text()
+		addr, err := mab.Parse(line)
+		if err != nil {
+			if err = lineErrs.fail(writer, lineN, 1, line, err); err != nil {
+				return err
+			}
+			continue
+		}
+		lineErrs.ok()
+
+		entry := opts.AuthorizedMAC(addr)
+		if file == MABFileUsers {
+			entry = opts.User(addr)
+		}
+
+		_, err = writer.WriteString(entry)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return lineErrs.result()
+}
+
+func mabVendorAction(w io.Writer, trie *registry.Trie, org *regexp.Regexp, attribute string, opts mab.Options) error {
+	records, err := organizationRecords(trie, org)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(w)
+
+	for _, record := range records {
+		entry, err := opts.Vendor(attribute, record.Assignment)
+		if err != nil {
+			return err //nolint: wrapcheck // already descriptive
+		}
+
+		_, err = writer.WriteString(fmt.Sprintf("# %s, %s %s\n", record.OrgName, record.Registry, record.Assignment))
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		_, err = writer.WriteString(entry)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -1,14 +1,11 @@
 package cmd
 
 import (
-	"bufio"
 	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	berrors "github.com/pkg/errors"
-
 	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
@@ -24,49 +21,42 @@ var modifiedCmd = &cobra.Command{
 			r = cmd.InOrStdin()
 		}
 
-		return modifiedAction(cmd.OutOrStdout(), r, flagEUIFormat)
+		table, err := tableOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return modifiedAction(cmd.OutOrStdout(), r, flagEUIFormat, table, newLineErrors(flagOnError, cmd.ErrOrStderr()))
 	},
 }
 
 func init() {
 	euiCmd.AddCommand(modifiedCmd)
+	addTableFlags(modifiedCmd)
 }
 
-func modifiedAction(w io.Writer, r io.Reader, format EUIFormat) error {
-	scanner := bufio.NewScanner(r)
-	writer := bufio.NewWriter(w)
+func modifiedAction(w io.Writer, r io.Reader, format EUIFormat, table tableOptions, lineErrs *lineErrors) error {
 	convertFunc := convertFuncMap[format]
 
-	var lineN int
-
-	for scanner.Scan() {
-		lineN++
-		line := scanner.Text()
-		addr, err := hwaddr.ParseAddr(line)
-		if err != nil {
-			return AtInputPositionError{Position: lineN, Err: err}
-		}
-
-		eui48, err := hwaddr.EUI48FromBytes(addr)
-		if err != nil {
-			return AtInputPositionError{Position: lineN, Err: err}
-		}
-
-		eui64 := eui48.EUI64Modified()
-
-		_, err = writer.WriteString(convertFunc(eui64[:]) + "\n")
-		if err != nil {
-			return berrors.WithStack(err)
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return berrors.WithStack(err)
-	}
-
-	err := writer.Flush()
-	if err != nil {
-		return berrors.WithStack(err)
-	}
-	return nil
+	return fieldProcessor{
+		table:    table,
+		lineErrs: lineErrs,
+		apply: func(value string) (string, error) {
+			addr, err := hwaddr.ParseAddr(value)
+			if err != nil {
+				return "", err //nolint: wrapcheck // already descriptive
+			}
+
+			eui48, err := hwaddr.EUI48FromBytes(addr)
+			if err != nil {
+				return "", err //nolint: wrapcheck // already descriptive
+			}
+
+			eui64 := eui48.EUI64Modified()
+
+			return convertFunc(eui64[:]), nil
+		},
+		annotate: "",
+		quiet:    false,
+	}.run(w, r)
 }
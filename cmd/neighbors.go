@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/netinfo"
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type NeighborResponse struct {
+	IP        string `json:"ip"`
+	MAC       string `json:"mac"`
+	Interface string `json:"interface"`
+	Permanent bool   `json:"permanent"`
+	Vendor    string `json:"vendor"`
+	Local     bool   `json:"local"`
+	Multicast bool   `json:"multicast"`
+}
+
+var neighborsCmd = &cobra.Command{
+	Use:          "neighbors",
+	Short:        "List the IPv4 neighbor table of the local Linux host",
+	Long:         neighborsResponseExample(),
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		root, err := cmd.Flags().GetString("root")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		return neighborsAction(cmd.OutOrStdout(), root, trie)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(neighborsCmd)
+	neighborsCmd.Flags().String("root", "/", "root of the filesystem holding procfs")
+}
+
+func neighborsAction(w io.Writer, root string, trie *registry.Trie) error {
+	neighbors, err := netinfo.Neighbors(root)
+	if err != nil {
+		return err //nolint: wrapcheck // already descriptive
+	}
+
+	writer := bufio.NewWriter(w)
+
+	for _, neighbor := range neighbors {
+		data, merr := json.Marshal(NeighborResponse{
+			IP:        neighbor.IP.String(),
+			MAC:       hwaddr.AsColon(neighbor.MAC),
+			Interface: neighbor.Interface,
+			Permanent: neighbor.Permanent,
+			Vendor:    vendorName(trie, neighbor.MAC),
+			Local:     hwaddr.IsLocal(neighbor.MAC),
+			Multicast: hwaddr.IsMulticast(neighbor.MAC),
+		})
+		if merr != nil {
+			return berrors.WithStack(merr)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func neighborsResponseExample() string {
+	data, err := json.MarshalIndent(NeighborResponse{
+		IP:        "192.168.1.1",
+		MAC:       "00:1b:21:0a:0b:0c",
+		Interface: "eth0",
+		Permanent: false,
+		Vendor:    "Intel Corporate",
+		Local:     false,
+		Multicast: false,
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Read the IPv4 neighbor table from /proc/net/arp and enrich entries with vendor
+names and U/L, I/G bits. Incomplete entries are skipped. --root points at
+another filesystem tree, e.g. a copy of procfs taken from another host. Example
+of the output:
+%s
+Requires the OUI database, see 'oui update'`, data)
+}
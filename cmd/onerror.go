@@ -0,0 +1,171 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/jsonpath"
+	"github.com/ttl256/euivator/internal/reservation"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(ABORT, SKIP, PASSTHROUGH, EMIT).
+type ErrorPolicy string //nolint: recvcheck // generated by a third-party
+
+var flagOnError = ErrorPolicyABORT
+
+// Exit codes of the utility.
+const (
+	exitFailure      = 1 // any error not related to a particular line of input
+	exitInvalidInput = 2 // processing is aborted on an invalid line
+	exitPartial      = 3 // all the input is processed but some lines are invalid
+)
+
+type ErrorResponse struct {
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Input  string `json:"input"`
+	Reason string `json:"reason"`
+	Error  string `json:"error"`
+}
+
+// PartialError reports that some lines of input were invalid under a policy
+// other than abort.
+type PartialError struct {
+	Failed int
+	Total  int
+}
+
+func (e PartialError) Error() string {
+	return fmt.Sprintf("%d of %d input lines are invalid", e.Failed, e.Total)
+}
+
+// errorReasons maps sentinel errors to stable names for [ErrorResponse].
+var errorReasons = []struct {
+	err    error
+	reason string
+}{
+	{hwaddr.ErrInputTooShort, "input_too_short"},
+	{hwaddr.ErrInputTooLong, "input_too_long"},
+	{hwaddr.ErrInputUnbalanced, "input_unbalanced"},
+	{hwaddr.ErrInputUnexpectedNumBytes, "input_unexpected_num_bytes"},
+	{hwaddr.ErrUnsupportedNAA, "unsupported_naa"},
+	{hwaddr.ErrUnsupportedDUIDType, "unsupported_duid_type"},
+	{errNoEUI, "no_eui"},
+	{hex.ErrLength, "invalid_hex"},
+	{errMissingField, "missing_field"},
+	{csv.ErrQuote, "invalid_csv"},
+	{csv.ErrBareQuote, "invalid_csv"},
+	{jsonpath.ErrNotFound, "path_not_found"},
+	{jsonpath.ErrNotObject, "not_json_object"},
+	{errNotString, "not_a_string"},
+}
+
+/*
+lineErrors applies an [ErrorPolicy] to invalid lines of input. Actions report
+every line they read with [lineErrors.ok] or [lineErrors.fail] and return
+[lineErrors.result] once the input is exhausted.
+*/
+type lineErrors struct {
+	policy ErrorPolicy
+	stderr io.Writer
+	failed int
+	total  int
+}
+
+func newLineErrors(policy ErrorPolicy, stderr io.Writer) *lineErrors {
+	return &lineErrors{policy: policy, stderr: stderr, failed: 0, total: 0}
+}
+
+func (e *lineErrors) ok() {
+	e.total++
+}
+
+/*
+fail handles an invalid line. Column counts from one and points at the part of
+the line that failed to parse. A non-nil error means that processing must stop.
+The line is written to w verbatim under the passthrough policy.
+*/
+func (e *lineErrors) fail(w io.Writer, lineN, column int, line string, err error) error {
+	e.total++
+	e.failed++
+
+	switch e.policy {
+	case ErrorPolicyABORT:
+		return AtInputPositionError{Position: lineN, Err: err}
+	case ErrorPolicySKIP:
+	case ErrorPolicyPASSTHROUGH:
+		if _, werr := io.WriteString(w, line+"\n"); werr != nil {
+			return berrors.WithStack(werr)
+		}
+	case ErrorPolicyEMIT:
+		data, merr := json.Marshal(ErrorResponse{
+			Line:   lineN,
+			Column: column,
+			Input:  line,
+			Reason: errorReason(err),
+			Error:  err.Error(),
+		})
+		if merr != nil {
+			return berrors.WithStack(merr)
+		}
+		if _, werr := e.stderr.Write(append(data, '\n')); werr != nil {
+			return berrors.WithStack(werr)
+		}
+	}
+
+	return nil
+}
+
+// result returns [PartialError] if any line was invalid.
+func (e *lineErrors) result() error {
+	if e.failed > 0 {
+		return PartialError{Failed: e.failed, Total: e.total}
+	}
+	return nil
+}
+
+func errorReason(err error) string {
+	for _, r := range errorReasons {
+		if errors.Is(err, r.err) {
+			return r.reason
+		}
+	}
+	if errors.As(err, new(hex.InvalidByteError)) {
+		return "invalid_hex"
+	}
+
+	return "invalid_input"
+}
+
+// columnOf returns the column of the first occurrence of a field in a line
+// counting from one.
+func columnOf(line, field string) int {
+	i := strings.Index(line, field)
+	if i < 0 {
+		return 1
+	}
+	return utf8.RuneCountInString(line[:i]) + 1
+}
+
+// exitCode maps an error returned by a command to an exit code.
+func exitCode(err error) int {
+	switch {
+	case errors.As(err, new(PartialError)):
+		return exitPartial
+	case errors.As(err, new(AtInputPositionError)), errors.As(err, new(reservation.LineError)):
+		return exitInvalidInput
+	default:
+		return exitFailure
+	}
+}
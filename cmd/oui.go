@@ -1,14 +1,6 @@
 package cmd
 
-import (
-	"path/filepath"
-
-	"github.com/adrg/xdg"
-	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
-)
-
-var cacheDir = filepath.Join(xdg.CacheHome, appName)
+import "github.com/spf13/cobra"
 
 var ouiCmd = &cobra.Command{
 	Use:   "oui",
@@ -17,8 +9,4 @@ var ouiCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(ouiCmd)
-	ouiCmd.PersistentFlags().String("cachedir", cacheDir, "Directory of the utility cache files")
-
-	_ = viper.BindPFlag("cachedir", ouiCmd.PersistentFlags().Lookup("cachedir"))
-	viper.SetDefault("cachedir", cacheDir)
 }
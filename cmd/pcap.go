@@ -0,0 +1,246 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/pcap"
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ENUM(JSON, TABLE).
+type CaptureOutput string //nolint: recvcheck // generated by a third-party
+
+// ENUM(mac, frames, first-seen).
+type CaptureSort string //nolint: recvcheck // generated by a third-party
+
+var (
+	flagCaptureOutput = CaptureOutputJSON
+	flagCaptureSort   = CaptureSortMac
+)
+
+type CaptureResponse struct {
+	MAC       string   `json:"mac"`
+	Vendor    string   `json:"vendor"`
+	Local     bool     `json:"local"`
+	Multicast bool     `json:"multicast"`
+	Frames    int      `json:"frames"`
+	Sent      int      `json:"sent"`
+	Received  int      `json:"received"`
+	FirstSeen string   `json:"first_seen"`
+	LastSeen  string   `json:"last_seen"`
+	IPs       []string `json:"ips"`
+}
+
+var pcapCmd = &cobra.Command{
+	Use:          "pcap [capture]",
+	Short:        "Inventory hosts of a packet capture",
+	Long:         pcapResponseExample(),
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		trie, err := loadTrie(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			return pcapAction(
+				cmd.OutOrStdout(), cmd.ErrOrStderr(), cmd.InOrStdin(), flagCaptureOutput, flagCaptureSort, trie,
+			)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		defer f.Close()
+
+		return pcapAction(cmd.OutOrStdout(), cmd.ErrOrStderr(), f, flagCaptureOutput, flagCaptureSort, trie)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(pcapCmd)
+	pcapCmd.Flags().Var(
+		&flagCaptureOutput,
+		"output",
+		"permitted options: "+strings.Join(CaptureOutputNames(), ", ")+" (case insensitive)",
+	)
+	pcapCmd.Flags().Var(
+		&flagCaptureSort,
+		"sort",
+		"permitted options: "+strings.Join(CaptureSortNames(), ", ")+"; frames are sorted in descending order",
+	)
+}
+
+func pcapAction(
+	w io.Writer, stderr io.Writer, r io.Reader, output CaptureOutput, order CaptureSort, trie *registry.Trie,
+) error {
+	reader, err := pcap.NewReader(r)
+	if err != nil {
+		return err //nolint: wrapcheck // already descriptive
+	}
+
+	hosts, skipped, err := pcap.Collect(reader)
+	if err != nil {
+		return err //nolint: wrapcheck // already descriptive
+	}
+	if err = warnSkipped(stderr, skipped); err != nil {
+		return err
+	}
+	sortHosts(hosts, order)
+
+	results := make([]CaptureResponse, 0, len(hosts))
+	for _, host := range hosts {
+		ips := make([]string, 0, len(host.IPs))
+		for _, ip := range host.IPs {
+			ips = append(ips, ip.String())
+		}
+
+		results = append(results, CaptureResponse{
+			MAC:       hwaddr.AsColon(host.MAC),
+			Vendor:    vendorName(trie, host.MAC),
+			Local:     hwaddr.IsLocal(host.MAC),
+			Multicast: hwaddr.IsMulticast(host.MAC),
+			Frames:    host.Sent + host.Received,
+			Sent:      host.Sent,
+			Received:  host.Received,
+			FirstSeen: formatSeen(host.First),
+			LastSeen:  formatSeen(host.Last),
+			IPs:       ips,
+		})
+	}
+
+	switch output {
+	case CaptureOutputTABLE:
+		return writeCaptureTable(w, results)
+	default:
+		return writeJSONLines(w, results)
+	}
+}
+
+// warnSkipped reports packets of interfaces of unsupported link types.
+func warnSkipped(stderr io.Writer, skipped map[uint32]int) error {
+	for _, linkType := range slices.Sorted(maps.Keys(skipped)) {
+		_, err := fmt.Fprintf(stderr, "skipped %d packets of unsupported link type %d\n", skipped[linkType], linkType)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// sortHosts keeps hosts of equal keys in the order of their MACs.
+func sortHosts(hosts []pcap.Host, order CaptureSort) {
+	switch order {
+	case CaptureSortFrames:
+		slices.SortStableFunc(hosts, func(a, b pcap.Host) int {
+			return cmp.Compare(b.Sent+b.Received, a.Sent+a.Received)
+		})
+	case CaptureSortFirstSeen:
+		// Hosts only seen in packets without timestamps go last.
+		slices.SortStableFunc(hosts, func(a, b pcap.Host) int {
+			switch {
+			case a.First.IsZero() == b.First.IsZero():
+				return a.First.Compare(b.First)
+			case a.First.IsZero():
+				return 1
+			default:
+				return -1
+			}
+		})
+	case CaptureSortMac:
+	}
+}
+
+// formatSeen leaves timestamps absent from a capture empty.
+func formatSeen(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.Format(time.RFC3339Nano)
+}
+
+func writeCaptureTable(w io.Writer, results []CaptureResponse) error {
+	const padding = 2
+
+	writer := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	_, err := fmt.Fprintln(writer, "MAC\tFRAMES\tSENT\tRECEIVED\tFIRST SEEN\tLAST SEEN\tIPS\tVENDOR")
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	for _, result := range results {
+		_, err = fmt.Fprintln(writer, strings.Join([]string{
+			result.MAC,
+			strconv.Itoa(result.Frames),
+			strconv.Itoa(result.Sent),
+			strconv.Itoa(result.Received),
+			result.FirstSeen,
+			result.LastSeen,
+			strings.Join(result.IPs, ","),
+			result.Vendor,
+		}, "\t"))
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func pcapResponseExample() string {
+	data, err := json.MarshalIndent(CaptureResponse{
+		MAC:       "00:1b:21:0a:0b:0c",
+		Vendor:    "Intel Corporate",
+		Local:     false,
+		Multicast: false,
+		Frames:    3,
+		Sent:      2,
+		Received:  1,
+		FirstSeen: "2023-11-14T22:13:20.0001Z",
+		LastSeen:  "2023-11-14T22:13:21.5Z",
+		IPs:       []string{"192.168.1.1"},
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Read a capture in the pcap or pcapng format from a file or the standard input
+and list every source and destination MAC with the number of frames it sent and
+received, when it was seen first and last and the IP addresses it used. Link
+types: Ethernet (802.1Q and QinQ tags are skipped), IEEE 802.11 and 802.11 with
+radiotap headers. Packets of other link types, e.g. of a loopback interface
+captured along with Ethernet ones, are skipped and counted on the standard
+error.
+
+IPs are the source addresses of IPv4 and IPv6 packets a MAC sent, so a router
+lists addresses of hosts it forwards packets of, and addresses from ARP. The
+output is a JSON per MAC or a table (--output), sorted by --sort. Example of the
+output:
+%s
+Requires the OUI database, see 'oui update'`, data)
+}
@@ -0,0 +1,122 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/reservation"
+)
+
+// ENUM(isc-dhcpd, kea-json, dnsmasq, ethers, openwrt-uci).
+type RenderTarget string //nolint: recvcheck // generated by a third-party
+
+// ENUM(ipv4, ipv6).
+type AddrFamily string //nolint: recvcheck // generated by a third-party
+
+var (
+	flagRenderTarget RenderTarget
+	flagRenderFamily = AddrFamilyIpv4
+)
+
+var renderers = map[RenderTarget]reservation.Renderer{
+	RenderTargetIscDhcpd:   reservation.ISCDhcpd,
+	RenderTargetKeaJson:    reservation.KeaJSON,
+	RenderTargetDnsmasq:    reservation.Dnsmasq,
+	RenderTargetEthers:     reservation.Ethers,
+	RenderTargetOpenwrtUci: reservation.OpenWrtUCI,
+}
+
+var renderFamilies = map[AddrFamily]reservation.Family{
+	AddrFamilyIpv4: reservation.IPv4,
+	AddrFamilyIpv6: reservation.IPv6,
+}
+
+var renderCmd = &cobra.Command{
+	Use:   "render --target target [inventory]",
+	Short: "Generate DHCP reservations and /etc/ethers from an inventory",
+	Long: `Generate DHCP reservations and /etc/ethers from an inventory of hosts. The
+inventory is a CSV read from a file or the standard input:
+
+hostname,mac,ipv4,ipv6
+laptop,00:1b:21:0a:0b:0c,192.168.1.10,2001:db8::10
+printer,00-0c-29-aa-bb-cc,192.168.1.20,
+
+The header is optional, without it columns go in the order above. ipv4 and
+ipv6 may be empty, lines starting with # are comments. MACs are normalized to
+lower case colon separated EUI-48s. Supported targets (--target):
+
+isc-dhcpd    host declarations of dhcpd, or of dhcpd -6 with --family ipv6
+kea-json     a list of Kea host reservations for Dhcp4, or Dhcp6 with --family
+             ipv6, to be included as the value of "reservations"
+dnsmasq      dhcp-host options with addresses of both families
+ethers       /etc/ethers lines mapping MACs to hostnames
+openwrt-uci  host sections of /etc/config/dhcp, the IPv6 address becomes the
+             hostid
+
+isc-dhcpd and kea-json leave out hosts without an address of the family.
+
+The whole inventory is validated before anything is written. Invalid hostnames
+and addresses, MACs and IPs used by more than one host, multicast MACs unless
+--allow-multicast and locally administered MACs unless --allow-local are all
+reported with their line numbers and nothing is rendered, the exit code is 2.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allowMulticast, err := cmd.Flags().GetBool("allow-multicast")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		allowLocal, err := cmd.Flags().GetBool("allow-local")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		opts := reservation.Options{AllowMulticast: allowMulticast, AllowLocal: allowLocal}
+
+		if len(args) == 0 {
+			return renderAction(cmd.OutOrStdout(), cmd.InOrStdin(), flagRenderTarget, flagRenderFamily, opts)
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		defer f.Close()
+
+		return renderAction(cmd.OutOrStdout(), f, flagRenderTarget, flagRenderFamily, opts)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(renderCmd)
+	renderCmd.Flags().Var(
+		&flagRenderTarget,
+		"target",
+		"configuration to generate, permitted options: "+strings.Join(RenderTargetNames(), ", "),
+	)
+	renderCmd.Flags().Var(
+		&flagRenderFamily,
+		"family",
+		"address family of isc-dhcpd and kea-json, permitted options: "+strings.Join(AddrFamilyNames(), ", "),
+	)
+	renderCmd.Flags().Bool("allow-multicast", false, "accept MACs with the I/G bit set")
+	renderCmd.Flags().Bool("allow-local", false, "accept locally administered MACs")
+	_ = renderCmd.MarkFlagRequired("target")
+}
+
+func renderAction(
+	w io.Writer, r io.Reader, target RenderTarget, family AddrFamily, opts reservation.Options,
+) error {
+	hosts, err := reservation.Read(r, opts)
+	if err != nil {
+		return err //nolint: wrapcheck // already descriptive
+	}
+
+	return renderers[target](w, hosts, renderFamilies[family]) //nolint: wrapcheck // already descriptive
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// maxLineLen bounds memory usage of commands that pass text through.
+const maxLineLen = 1 << 20
+
+var errLineTooLong = errors.New("line is too long")
+
+var rewriteCmd = &cobra.Command{
+	Use:   "rewrite [text ...]",
+	Short: "Normalize EUIs inside a text stream",
+	Long: `Pass text through unchanged except that every recognized EUI is rewritten
+according to --format. With --vendor the name of the organization owning the
+OUI is appended to each EUI in brackets, this requires the OUI database (see
+'oui update'). Output is flushed whenever the input is idle, so the command can
+sit in a 'tail -F' pipeline. Lines are limited to 1MiB.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		vendor, err := cmd.Flags().GetBool("vendor")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		var trie *registry.Trie
+		if vendor {
+			trie, err = loadTrie(viper.GetString("cachedir"))
+			if err != nil {
+				return err
+			}
+		}
+
+		return rewriteAction(cmd.OutOrStdout(), r, flagEUIFormat, trie)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(rewriteCmd)
+	rewriteCmd.Flags().Bool("vendor", false, "append the vendor name in brackets after each EUI")
+}
+
+// rewriteAction appends vendor names when trie is not nil.
+func rewriteAction(w io.Writer, r io.Reader, format EUIFormat, trie *registry.Trie) error {
+	convertFunc := convertFuncMap[format]
+
+	return rewriteLines(w, r, func(line string) string {
+		return hwaddr.ReplaceAll(line, func(m hwaddr.Match) string {
+			converted := convertFunc(m.Addr)
+			if trie == nil {
+				return converted
+			}
+			if vendor := vendorName(trie, m.Addr); vendor != "" {
+				return converted + " [" + vendor + "]"
+			}
+			return converted
+		})
+	})
+}
+
+/*
+rewriteLines applies a function to every line of the input preserving line
+endings. Output is flushed when there is no more buffered input so that results
+show up as soon as a line arrives.
+*/
+func rewriteLines(w io.Writer, r io.Reader, rewrite func(line string) string) error {
+	reader := bufio.NewReaderSize(r, maxLineLen)
+	writer := bufio.NewWriter(w)
+
+	var lineN int
+
+	for {
+		line, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			return AtInputPositionError{
+				Position: lineN + 1, Err: fmt.Errorf("%w: exceeds %d bytes", errLineTooLong, maxLineLen),
+			}
+		}
+		if err != nil && !errors.Is(err, io.EOF) {
+			return berrors.WithStack(err)
+		}
+
+		if len(line) > 0 {
+			lineN++
+			if _, werr := writer.WriteString(rewrite(string(line))); werr != nil {
+				return berrors.WithStack(werr)
+			}
+		}
+
+		if errors.Is(err, io.EOF) || reader.Buffered() == 0 {
+			if ferr := writer.Flush(); ferr != nil {
+				return berrors.WithStack(ferr)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+	}
+}
+
+// vendorName returns names of organizations owning an address joined by
+// semicolons or an empty string when the address is not registered.
+func vendorName(trie *registry.Trie, addr []byte) string {
+	return orgNames(trie.LongestPrefixMatch(hexPrefix(addr)))
+}
+
+// orgNames joins distinct organization names of records by semicolons.
+func orgNames(records []registry.Record) string {
+	names := make([]string, 0, len(records))
+	for _, record := range records {
+		if !slices.Contains(names, record.OrgName) {
+			names = append(names, record.OrgName)
+		}
+	}
+
+	return strings.Join(names, "; ")
+}
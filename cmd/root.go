@@ -15,6 +15,7 @@ import (
 
 var (
 	defaultConfigPath = filepath.Join(xdg.ConfigHome, appName)
+	cacheDir          = filepath.Join(xdg.CacheHome, appName)
 	logger            = new(slog.Logger)
 	logLevel          = new(slog.LevelVar)
 )
@@ -25,6 +26,12 @@ var rootCmd = &cobra.Command{
 	Use:   appName,
 	Short: "A CLI tool to work with EUIs",
 	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		policy, err := ParseErrorPolicy(viper.GetString("on-error"))
+		if err != nil {
+			return err
+		}
+		flagOnError = policy
+
 		debug := viper.GetBool("debug")
 		if debug {
 			logLevel.Set(slog.LevelDebug)
@@ -38,7 +45,7 @@ var rootCmd = &cobra.Command{
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -63,6 +70,12 @@ func init() {
 		),
 	)
 	rootCmd.PersistentFlags().Bool("debug", false, "enable verbose logging")
+	rootCmd.PersistentFlags().String("cachedir", cacheDir, "Directory of the utility cache files")
+	rootCmd.PersistentFlags().Var(
+		&flagOnError,
+		"on-error",
+		"policy for invalid lines, permitted options: "+strings.Join(ErrorPolicyNames(), ", ")+" (case insensitive)",
+	)
 	rootCmd.SetVersionTemplate(`{{printf "%s\n" .Version}}`)
 
 	replacer := strings.NewReplacer("-", "_")
@@ -70,6 +83,10 @@ func init() {
 	viper.SetEnvPrefix("EUIVATOR")
 
 	_ = viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	_ = viper.BindPFlag("cachedir", rootCmd.PersistentFlags().Lookup("cachedir"))
+	viper.SetDefault("cachedir", cacheDir)
+	_ = viper.BindPFlag("on-error", rootCmd.PersistentFlags().Lookup("on-error"))
+	viper.SetDefault("on-error", string(ErrorPolicyABORT))
 }
 
 func initConfig() {
@@ -0,0 +1,151 @@
+//go:generate go-enum --names --values --lower --flag
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/registry"
+)
+
+// ENUM(substring, regex, fuzzy).
+type SearchMode string //nolint: recvcheck // generated by a third-party
+
+var flagSearchMode = SearchModeSubstring
+
+type SearchResponse struct {
+	Assignment string        `json:"assignment"`
+	Registry   registry.Name `json:"registry"`
+	OrgName    string        `json:"org_name"`
+	OrgAddress string        `json:"org_address"`
+	// Size is the number of EUI-48s in the assignment.
+	Size uint64 `json:"size"`
+}
+
+var searchCmd = &cobra.Command{
+	Use:          "search query ...",
+	Short:        "Search the OUI database by organization",
+	Long:         searchResponseExample(),
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		address, err := cmd.Flags().GetBool("address")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		index, err := loadIndex(viper.GetString("cachedir"))
+		if err != nil {
+			return err
+		}
+
+		return searchAction(cmd.OutOrStdout(), index, strings.Join(args, " "), flagSearchMode, address)
+	},
+}
+
+func init() {
+	ouiCmd.AddCommand(searchCmd)
+	searchCmd.Flags().Var(
+		&flagSearchMode,
+		"mode",
+		"how the query matches, permitted options: "+strings.Join(SearchModeNames(), ", "),
+	)
+	searchCmd.Flags().Bool("address", false, "match addresses of organizations as well as names")
+}
+
+func searchAction(w io.Writer, index *registry.Index, query string, mode SearchMode, address bool) error {
+	var records []registry.Record
+	switch mode {
+	case SearchModeSubstring:
+		records = index.Substring(query, address)
+	case SearchModeRegex:
+		re, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return fmt.Errorf("parsing query: %w", err)
+		}
+		records = index.Regexp(re, address)
+	case SearchModeFuzzy:
+		records = index.Fuzzy(query, address)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("%w: %q", errNoOrganization, query)
+	}
+
+	results := make([]SearchResponse, 0, len(records))
+	for _, record := range records {
+		results = append(results, SearchResponse{
+			Assignment: record.Assignment,
+			Registry:   record.Registry,
+			OrgName:    record.OrgName,
+			OrgAddress: record.OrgAddress,
+			Size:       record.Size(),
+		})
+	}
+
+	return writeJSONLines(w, results)
+}
+
+func loadIndex(dir string) (*registry.Index, error) {
+	indexFile := filepath.Join(dir, IndexFile)
+	f, err := os.Open(indexFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf(
+				"unable to open %q. try running '%s oui update' to prepare required cache",
+				indexFile,
+				appName,
+			)
+		}
+		return nil, berrors.WithStack(err)
+	}
+	defer f.Close()
+
+	var index registry.Index
+	err = index.DecodeGOB(f)
+	if err != nil {
+		return nil, fmt.Errorf("loading search index: %w", err)
+	}
+
+	return &index, nil
+}
+
+func searchResponseExample() string {
+	data, err := json.MarshalIndent(SearchResponse{
+		Assignment: "245A4C",
+		Registry:   registry.NameMAL,
+		OrgName:    "Ubiquiti Inc",
+		OrgAddress: "685 Third Avenue New York NY US 10017",
+		Size:       16777216,
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	return fmt.Sprintf(`Search the OUI database by names of organizations, the reverse of 'oui
+lookup'. Words of the query are joined with spaces. Modes (--mode):
+
+substring  names containing the query, e.g. "ubiquiti" or "tel corp"
+regex      names matching a regular expression
+fuzzy      names having every word of the query, possibly misspelled, e.g.
+           "ubiqiti inc", the closest come first
+
+All modes ignore case and diacritics: "societe" finds "Société". --address
+matches addresses of organizations too. Matching assignments of all registries
+are emitted a JSON per line with the number of EUI-48s they hold:
+
+%s
+
+Requires the search index, see 'oui update'.`, data)
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type SetResponse struct {
+	Input string   `json:"input"`
+	Files []string `json:"files"`
+}
+
+type setOperation int
+
+const (
+	setUnion setOperation = iota
+	setIntersect
+	setDiff
+)
+
+const (
+	stdinFileName  = "-"
+	setMinOperands = 2
+)
+
+var errStdinTwice = errors.New("standard input may be given only once")
+
+var setCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Set operations on lists of EUIs",
+	Long:  setResponseExample(),
+}
+
+var setUnionCmd = &cobra.Command{
+	Use:          "union file file...",
+	Short:        "EUIs found in any of the files",
+	Args:         cobra.MinimumNArgs(setMinOperands),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAction(cmd.OutOrStdout(), cmd.InOrStdin(), args, setUnion, flagEUIFormat)
+	},
+}
+
+var setIntersectCmd = &cobra.Command{
+	Use:          "intersect file file...",
+	Short:        "EUIs found in all of the files",
+	Args:         cobra.MinimumNArgs(setMinOperands),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAction(cmd.OutOrStdout(), cmd.InOrStdin(), args, setIntersect, flagEUIFormat)
+	},
+}
+
+var setDiffCmd = &cobra.Command{
+	Use:          "diff file file...",
+	Short:        "EUIs found in the first file but in none of the others",
+	Args:         cobra.MinimumNArgs(setMinOperands),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAction(cmd.OutOrStdout(), cmd.InOrStdin(), args, setDiff, flagEUIFormat)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(setCmd)
+	setCmd.AddCommand(setUnionCmd)
+	setCmd.AddCommand(setIntersectCmd)
+	setCmd.AddCommand(setDiffCmd)
+}
+
+// setAction reads "-" from stdin.
+func setAction(w io.Writer, stdin io.Reader, files []string, op setOperation, format EUIFormat) error {
+	if countStdin(files) > 1 {
+		return errStdinTwice
+	}
+
+	var (
+		addrs   [][]byte
+		sources = make(map[string][]int) // indices of files an address is found in
+	)
+
+	for i, name := range files {
+		fileAddrs, err := readAddrsFile(name, stdin)
+		if err != nil {
+			return err
+		}
+
+		for _, addr := range fileAddrs {
+			key := string(addr)
+			idx, ok := sources[key]
+			if !ok {
+				addrs = append(addrs, addr)
+			}
+			if !slices.Contains(idx, i) {
+				sources[key] = append(idx, i)
+			}
+		}
+	}
+
+	slices.SortFunc(addrs, hwaddr.Compare)
+
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+
+	for _, addr := range addrs {
+		idx := sources[string(addr)]
+
+		switch op {
+		case setUnion:
+		case setIntersect:
+			if len(idx) != len(files) {
+				continue
+			}
+		case setDiff:
+			if len(idx) != 1 || idx[0] != 0 {
+				continue
+			}
+		}
+
+		resp := SetResponse{Input: convertFunc(addr), Files: make([]string, 0, len(idx))}
+		for _, i := range idx {
+			resp.Files = append(resp.Files, files[i])
+		}
+
+		data, err := json.Marshal(resp)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		data = append(data, '\n')
+
+		_, err = writer.Write(data)
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+func readAddrsFile(name string, stdin io.Reader) ([][]byte, error) {
+	if name == stdinFileName {
+		addrs, err := readAddrs(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return addrs, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, berrors.WithStack(err)
+	}
+	defer f.Close()
+
+	addrs, err := readAddrs(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return addrs, nil
+}
+
+func countStdin(files []string) int {
+	var n int
+	for _, name := range files {
+		if name == stdinFileName {
+			n++
+		}
+	}
+	return n
+}
+
+func setResponseExample() string {
+	data, err := json.MarshalIndent(SetResponse{
+		Input: "00:aa:11:bb:22:cc",
+		Files: []string{"switch1.txt", "switch2.txt"},
+	}, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf(`Set operations on lists of EUIs, one EUI per line in any notation. Every
+operation takes two or more files, "-" stands for the standard input. Output is
+a JSON per EUI in numeric order (see 'eui sort') with the files the EUI is found
+in. Example of the output:
+%s
+The input key is formatted according to --format`, data)
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+var sortCmd = &cobra.Command{
+	Use:   "sort [eui ...]",
+	Short: "Sort EUIs numerically regardless of notation",
+	Long: `Sort EUIs numerically regardless of notation. EUI-48 come before EUI-64,
+addresses of the same length are ordered by value. Output is formatted according
+to --format.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		flags := cmd.Flags()
+
+		unique, err := flags.GetBool("unique")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		reverse, err := flags.GetBool("reverse")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+
+		return sortAction(cmd.OutOrStdout(), r, flagEUIFormat, unique, reverse)
+	},
+}
+
+var uniqCmd = &cobra.Command{
+	Use:   "uniq [eui ...]",
+	Short: "Drop repeated EUIs regardless of notation",
+	Long: `Drop repeated EUIs regardless of notation keeping the first occurrence of
+every EUI in the input order. Unlike uniq(1) the input does not have to be
+sorted. Output is formatted according to --format.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var r io.Reader
+		if len(args) > 0 {
+			r = strings.NewReader(strings.Join(args, "\n"))
+		} else {
+			r = cmd.InOrStdin()
+		}
+
+		return uniqAction(cmd.OutOrStdout(), r, flagEUIFormat)
+	},
+}
+
+func init() {
+	euiCmd.AddCommand(sortCmd)
+	euiCmd.AddCommand(uniqCmd)
+	sortCmd.Flags().BoolP("unique", "u", false, "output only the first of equal EUIs")
+	sortCmd.Flags().BoolP("reverse", "r", false, "reverse the result of comparisons")
+}
+
+func sortAction(w io.Writer, r io.Reader, format EUIFormat, unique, reverse bool) error {
+	addrs, err := readAddrs(r)
+	if err != nil {
+		return err
+	}
+
+	slices.SortStableFunc(addrs, hwaddr.Compare)
+	if unique {
+		addrs = slices.CompactFunc(addrs, func(a, b []byte) bool { return hwaddr.Compare(a, b) == 0 })
+	}
+	if reverse {
+		slices.Reverse(addrs)
+	}
+
+	return writeAddrs(w, addrs, format)
+}
+
+func uniqAction(w io.Writer, r io.Reader, format EUIFormat) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+	seen := make(map[string]struct{})
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		addr, err := hwaddr.ParseAddr(scanner.Text())
+		if err != nil {
+			return AtInputPositionError{Position: lineN, Err: err}
+		}
+
+		key := string(addr)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		_, err = writer.WriteString(convertFunc(addr) + "\n")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// readAddrs parses an EUI per line.
+func readAddrs(r io.Reader) ([][]byte, error) {
+	scanner := bufio.NewScanner(r)
+
+	var (
+		addrs [][]byte
+		lineN int
+	)
+
+	for scanner.Scan() {
+		lineN++
+		addr, err := hwaddr.ParseAddr(scanner.Text())
+		if err != nil {
+			return nil, AtInputPositionError{Position: lineN, Err: err}
+		}
+		addrs = append(addrs, addr)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, berrors.WithStack(err)
+	}
+
+	return addrs, nil
+}
+
+func writeAddrs(w io.Writer, addrs [][]byte, format EUIFormat) error {
+	writer := bufio.NewWriter(w)
+	convertFunc := convertFuncMap[format]
+
+	for _, addr := range addrs {
+		_, err := writer.WriteString(convertFunc(addr) + "\n")
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
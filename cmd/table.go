@@ -0,0 +1,468 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	berrors "github.com/pkg/errors"
+
+	"github.com/ttl256/euivator/internal/jsonpath"
+)
+
+var (
+	errMissingField     = errors.New("missing field")
+	errUnknownField     = errors.New("no such column in the header")
+	errInvalidDelimiter = errors.New("invalid delimiter")
+	errNotString        = errors.New("value is not a string")
+)
+
+type tableOptions struct {
+	field     int // counts from one, zero processes whole lines
+	fieldName string
+	header    bool
+	delimiter rune          // zero splits rows on runs of whitespace
+	jsonPath  jsonpath.Path // input is NDJSON when set
+	jsonSet   jsonpath.Path // where results go, jsonPath by default
+}
+
+func (t tableOptions) enabled() bool {
+	return t.field > 0 || t.fieldName != "" || t.jsonPath != nil
+}
+
+func (t tableOptions) hasHeader() bool {
+	return t.header || t.fieldName != ""
+}
+
+func addTableFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("field", 0, "process only a given column counting from 1")
+	cmd.Flags().String("field-name", "", "process only a column with a given name in the header row")
+	cmd.Flags().String(
+		"delimiter", "", `column delimiter, e.g. "," or "\t", enables CSV quoting (default is runs of whitespace)`,
+	)
+	cmd.Flags().Bool("header", false, "pass the first row through as a header (implied by --field-name)")
+	cmd.Flags().String("json-path", "", "read NDJSON and process a string at a given path, e.g. .src.mac")
+	cmd.Flags().String("json-set", "", "path to write the result to, e.g. .src.vendor")
+	cmd.MarkFlagsMutuallyExclusive("field", "field-name", "json-path")
+	cmd.MarkFlagsMutuallyExclusive("delimiter", "json-path")
+	cmd.MarkFlagsMutuallyExclusive("header", "json-path")
+}
+
+func tableOptionsFromFlags(cmd *cobra.Command) (tableOptions, error) {
+	flags := cmd.Flags()
+
+	var (
+		t   tableOptions
+		err error
+	)
+
+	if t.field, err = flags.GetInt("field"); err != nil {
+		return tableOptions{}, berrors.WithStack(err)
+	}
+	if t.field < 0 {
+		return tableOptions{}, fmt.Errorf("field must be a positive integer, got %d", t.field)
+	}
+	if t.fieldName, err = flags.GetString("field-name"); err != nil {
+		return tableOptions{}, berrors.WithStack(err)
+	}
+	if t.header, err = flags.GetBool("header"); err != nil {
+		return tableOptions{}, berrors.WithStack(err)
+	}
+
+	delimiter, err := flags.GetString("delimiter")
+	if err != nil {
+		return tableOptions{}, berrors.WithStack(err)
+	}
+	if t.delimiter, err = parseDelimiter(delimiter); err != nil {
+		return tableOptions{}, err
+	}
+	if t.delimiter != 0 && !t.enabled() {
+		return tableOptions{}, errors.New("--delimiter requires --field or --field-name")
+	}
+
+	if t.jsonPath, err = jsonPathFlag(cmd, "json-path"); err != nil {
+		return tableOptions{}, err
+	}
+	if t.jsonSet, err = jsonPathFlag(cmd, "json-set"); err != nil {
+		return tableOptions{}, err
+	}
+	if t.jsonSet != nil && t.jsonPath == nil {
+		return tableOptions{}, errors.New("--json-set requires --json-path")
+	}
+
+	return t, nil
+}
+
+// jsonPathFlag returns nil if a flag is not set.
+func jsonPathFlag(cmd *cobra.Command, name string) (jsonpath.Path, error) {
+	raw, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return nil, berrors.WithStack(err)
+	}
+	if raw == "" {
+		return nil, nil
+	}
+
+	path, err := jsonpath.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %w", name, err)
+	}
+
+	return path, nil
+}
+
+// parseDelimiter accepts a single character, a tab may be spelled as \t.
+func parseDelimiter(s string) (rune, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if s == `\t` {
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("%w %q: expected a single character other than a quote or a newline", errInvalidDelimiter, s)
+	}
+
+	return r, nil
+}
+
+/*
+fieldProcessor applies a function to a single column of tabular input or to a
+string field of NDJSON objects passing the rest of every row through untouched,
+or to whole lines when no column is selected. The result either replaces the
+column or is appended to the row as a new column when annotate is set, annotate
+is also the name of the column in the header row and the name of a JSON field
+written next to the input one unless --json-set is given.
+*/
+type fieldProcessor struct {
+	table    tableOptions
+	lineErrs *lineErrors
+	apply    func(value string) (string, error)
+	annotate string
+	quiet    bool // only invalid rows are written under the passthrough policy
+}
+
+func (p fieldProcessor) run(w io.Writer, r io.Reader) error {
+	switch {
+	case p.table.jsonPath != nil:
+		return p.runJSON(w, r)
+	case p.table.delimiter != 0:
+		return p.runCSV(w, r)
+	default:
+		return p.runFields(w, r)
+	}
+}
+
+func (p fieldProcessor) runFields(w io.Writer, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	writer := bufio.NewWriter(w)
+	idx := p.table.field - 1
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		line := scanner.Text()
+
+		var (
+			out   string
+			spans [][2]int
+		)
+		if p.table.enabled() {
+			spans = fieldSpans(line)
+		}
+
+		if lineN == 1 && p.table.hasHeader() {
+			if p.table.fieldName != "" {
+				names := make([]string, 0, len(spans))
+				for _, span := range spans {
+					names = append(names, line[span[0]:span[1]])
+				}
+				var err error
+				if idx, err = columnIndex(names, p.table.fieldName); err != nil {
+					return err
+				}
+			}
+			if p.quiet {
+				continue
+			}
+			out = line
+			if p.annotate != "" {
+				out += "\t" + p.annotate
+			}
+		} else {
+			start, end := 0, len(line)
+			if p.table.enabled() {
+				if idx >= len(spans) {
+					err := fmt.Errorf("%w %d", errMissingField, idx+1)
+					if err = p.lineErrs.fail(writer, lineN, 1, line, err); err != nil {
+						return err
+					}
+					continue
+				}
+				start, end = spans[idx][0], spans[idx][1]
+			}
+
+			value, err := p.apply(line[start:end])
+			if err != nil {
+				column := utf8.RuneCountInString(line[:start]) + 1
+				if err = p.lineErrs.fail(writer, lineN, column, line, err); err != nil {
+					return err
+				}
+				continue
+			}
+			p.lineErrs.ok()
+
+			if p.quiet {
+				continue
+			}
+			if p.annotate != "" {
+				out = line + "\t" + value
+			} else {
+				out = line[:start] + value + line[end:]
+			}
+		}
+
+		if _, err := writer.WriteString(out + "\n"); err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return p.lineErrs.result()
+}
+
+func (p fieldProcessor) runCSV(w io.Writer, r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.Comma = p.table.delimiter
+	reader.FieldsPerRecord = -1
+	// Stray quotes inside unquoted fields are common in exported tables.
+	reader.LazyQuotes = true
+	writer := csv.NewWriter(w)
+	writer.Comma = p.table.delimiter
+	idx := p.table.field - 1
+
+	// Invalid rows are written around the CSV writer, flush it first.
+	fail := func(record []string, lineN, column int, err error) error {
+		writer.Flush()
+		if werr := writer.Error(); werr != nil {
+			return berrors.WithStack(werr)
+		}
+		return p.lineErrs.fail(w, lineN, column, csvRow(record, p.table.delimiter), err)
+	}
+
+	for rowN := 1; ; rowN++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			if err = fail(record, perr.StartLine, perr.Column, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return berrors.WithStack(err)
+		}
+		lineN, _ := reader.FieldPos(0)
+
+		if rowN == 1 && p.table.hasHeader() {
+			if p.table.fieldName != "" {
+				if idx, err = columnIndex(record, p.table.fieldName); err != nil {
+					return err
+				}
+			}
+			if p.quiet {
+				continue
+			}
+			if p.annotate != "" {
+				record = append(record, p.annotate)
+			}
+			if err = writer.Write(record); err != nil {
+				return berrors.WithStack(err)
+			}
+			continue
+		}
+
+		if idx >= len(record) {
+			if err = fail(record, lineN, 1, fmt.Errorf("%w %d", errMissingField, idx+1)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := p.apply(record[idx])
+		if err != nil {
+			_, column := reader.FieldPos(idx)
+			if err = fail(record, lineN, column, err); err != nil {
+				return err
+			}
+			continue
+		}
+		p.lineErrs.ok()
+
+		if p.quiet {
+			continue
+		}
+		if p.annotate != "" {
+			record = append(record, value)
+		} else {
+			record[idx] = value
+		}
+		if err = writer.Write(record); err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return p.lineErrs.result()
+}
+
+// runJSON skips blank lines.
+func (p fieldProcessor) runJSON(w io.Writer, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxLineLen)
+	writer := bufio.NewWriter(w)
+
+	setPath := p.table.jsonSet
+	if setPath == nil {
+		setPath = p.table.jsonPath
+		if p.annotate != "" {
+			setPath = p.table.jsonPath.Sibling(p.annotate)
+		}
+	}
+
+	process := func(line []byte) ([]byte, error) {
+		raw, err := jsonpath.Get(line, p.table.jsonPath)
+		if err != nil {
+			return nil, err //nolint: wrapcheck // already descriptive
+		}
+
+		var input string
+		if err = json.Unmarshal(raw, &input); err != nil {
+			return nil, fmt.Errorf("%w at %s", errNotString, p.table.jsonPath)
+		}
+
+		value, err := p.apply(input)
+		if err != nil {
+			return nil, err
+		}
+
+		// Vendor names like AT&T are kept readable.
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err = enc.Encode(value); err != nil {
+			return nil, berrors.WithStack(err)
+		}
+
+		return jsonpath.Set(line, setPath, bytes.TrimSuffix(buf.Bytes(), []byte("\n"))) //nolint: wrapcheck // already descriptive
+	}
+
+	var lineN int
+
+	for scanner.Scan() {
+		lineN++
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		out, err := process(line)
+		if err != nil {
+			if err = p.lineErrs.fail(writer, lineN, 1, string(line), err); err != nil {
+				return err
+			}
+			continue
+		}
+		p.lineErrs.ok()
+
+		if p.quiet {
+			continue
+		}
+		if _, err = writer.Write(append(out, '\n')); err != nil {
+			return berrors.WithStack(err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return berrors.WithStack(err)
+	}
+
+	err := writer.Flush()
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return p.lineErrs.result()
+}
+
+func columnIndex(header []string, name string) (int, error) {
+	idx := slices.Index(header, name)
+	if idx < 0 {
+		return 0, fmt.Errorf("%w: %q", errUnknownField, name)
+	}
+	return idx, nil
+}
+
+// csvRow encodes a record without the trailing newline.
+func csvRow(record []string, delimiter rune) string {
+	buf := new(strings.Builder)
+	writer := csv.NewWriter(buf)
+	writer.Comma = delimiter
+	_ = writer.Write(record)
+	writer.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// fieldSpans returns byte offsets of whitespace-separated fields of a line.
+func fieldSpans(line string) [][2]int {
+	var spans [][2]int
+
+	for i := 0; i < len(line); {
+		start := strings.IndexFunc(line[i:], func(r rune) bool { return !unicode.IsSpace(r) })
+		if start < 0 {
+			break
+		}
+		start += i
+
+		end := strings.IndexFunc(line[start:], unicode.IsSpace)
+		if end < 0 {
+			end = len(line)
+		} else {
+			end += start
+		}
+
+		spans = append(spans, [2]int{start, end})
+		i = end
+	}
+
+	return spans
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -56,21 +57,17 @@ func updateAction(cmd *cobra.Command, dir string, logger *slog.Logger) error {
 	}
 	logger.LogAttrs(cmd.Context(), slog.LevelDebug, "prepared lookup data structure")
 
-	f, err := os.OpenFile(
-		filepath.Join(dir, LookupFile),
-		os.O_CREATE|os.O_TRUNC|os.O_WRONLY,
-		0644,
-	)
+	err = dumpGOB(filepath.Join(dir, LookupFile), trie.EncodeGOB)
 	if err != nil {
-		return berrors.WithStack(err)
+		return err
 	}
-	defer f.Close()
+	logger.LogAttrs(cmd.Context(), slog.LevelDebug, "dumped lookup data structure on disk")
 
-	err = trie.EncodeGOB(f)
+	err = dumpGOB(filepath.Join(dir, IndexFile), registry.NewIndex(trie.Traverse()).EncodeGOB)
 	if err != nil {
-		return berrors.WithStack(err)
+		return err
 	}
-	logger.LogAttrs(cmd.Context(), slog.LevelDebug, "dumped lookup data structure on disk")
+	logger.LogAttrs(cmd.Context(), slog.LevelDebug, "dumped search index on disk")
 	logger.LogAttrs(cmd.Context(), slog.LevelInfo, "all done")
 
 	return nil
@@ -93,3 +90,18 @@ func appendFromCVStoTrie(ctx context.Context, t *registry.Trie, path string, log
 
 	return nil
 }
+
+func dumpGOB(path string, encode func(w io.Writer) error) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+	defer f.Close()
+
+	err = encode(f)
+	if err != nil {
+		return berrors.WithStack(err)
+	}
+
+	return nil
+}
@@ -4,14 +4,11 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"bufio"
 	"io"
 	"strings"
 
 	"github.com/spf13/cobra"
 
-	berrors "github.com/pkg/errors"
-
 	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
@@ -32,31 +29,30 @@ XXXXXXXXXXXX`,
 			r = cmd.InOrStdin()
 		}
 
-		return verifyAction(r)
+		table, err := tableOptionsFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		return verifyAction(cmd.OutOrStdout(), r, table, newLineErrors(flagOnError, cmd.ErrOrStderr()))
 	},
 }
 
 func init() {
 	euiCmd.AddCommand(verifyCmd)
+	addTableFlags(verifyCmd)
 }
 
-func verifyAction(r io.Reader) error {
-	scanner := bufio.NewScanner(r)
-
-	var lineN int
-
-	for scanner.Scan() {
-		lineN++
-		line := scanner.Text()
-		_, err := hwaddr.ParseAddr(line)
-		if err != nil {
-			return AtInputPositionError{Position: lineN, Err: err}
-		}
-	}
-
-	if err := scanner.Err(); err != nil {
-		return berrors.WithStack(err)
-	}
-
-	return nil
+// verifyAction writes nothing but invalid lines under the passthrough policy.
+func verifyAction(w io.Writer, r io.Reader, table tableOptions, lineErrs *lineErrors) error {
+	return fieldProcessor{
+		table:    table,
+		lineErrs: lineErrs,
+		apply: func(value string) (string, error) {
+			_, err := hwaddr.ParseAddr(value)
+			return value, err //nolint: wrapcheck // already descriptive
+		},
+		annotate: "",
+		quiet:    true,
+	}.run(w, r)
 }
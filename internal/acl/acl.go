@@ -0,0 +1,99 @@
+/*
+Package acl renders blocks of MACs into access lists and filters of switches,
+firewalls and packet capture tools. Blocks are [hwaddr.Block]s holding a MAC in
+the first 48 bits of the prefix.
+*/
+package acl
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+const (
+	byteBits = 8
+	// macBits is the length of an EUI-48 within an [hwaddr.EUI64] block prefix.
+	macBits = byteBits * hwaddr.EUI48Len
+)
+
+// Options of a rendered access list.
+type Options struct {
+	// Deny matching MACs rather than allow them.
+	Deny bool
+	// Name of a Cisco access list or a Junos filter and term.
+	Name string
+	// Chain of iptables and ebtables rules.
+	Chain string
+}
+
+/*
+Aggregate drops blocks covered by other blocks and merges pairs of adjacent
+blocks of the same length into a block one bit shorter, repeatedly. The result
+is sorted and covers exactly the same addresses as the input.
+*/
+func Aggregate(blocks []hwaddr.Block) []hwaddr.Block {
+	sorted := slices.Clone(blocks)
+	slices.SortFunc(sorted, func(a, b hwaddr.Block) int {
+		if c := cmp.Compare(a.Prefix.Uint64(), b.Prefix.Uint64()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Bits, b.Bits)
+	})
+
+	var result []hwaddr.Block
+	for _, block := range sorted {
+		if n := len(result); n > 0 && result[n-1].Contains(block.Prefix) {
+			continue
+		}
+		result = append(result, block)
+
+		for n := len(result); n >= 2; n = len(result) {
+			parent, ok := merge(result[n-2], result[n-1])
+			if !ok {
+				break
+			}
+			result = append(result[:n-2], parent)
+		}
+	}
+
+	return result
+}
+
+// merge returns the parent of two sibling blocks.
+func merge(a, b hwaddr.Block) (hwaddr.Block, bool) {
+	if a.Bits != b.Bits || a.Bits == 0 {
+		return hwaddr.Block{}, false
+	}
+
+	bit := uint64(1) << (64 - a.Bits)
+	if a.Prefix.Uint64()&bit != 0 || a.Prefix.Uint64()|bit != b.Prefix.Uint64() {
+		return hwaddr.Block{}, false
+	}
+
+	return hwaddr.Block{Prefix: a.Prefix, Bits: a.Bits - 1}, true
+}
+
+// MAC returns the first MAC of a block.
+func MAC(block hwaddr.Block) []byte {
+	return block.Prefix[:hwaddr.EUI48Len]
+}
+
+// Mask returns the mask of a block as a MAC, ones mark the bits of the prefix.
+func Mask(block hwaddr.Block) []byte {
+	mask := hwaddr.EUI64FromUint64(^uint64(0) << (64 - min(block.Bits, macBits)))
+	return mask[:hwaddr.EUI48Len]
+}
+
+// Last returns the last MAC of a block.
+func Last(block hwaddr.Block) []byte {
+	hostBits := ^uint64(0) >> min(block.Bits, macBits)
+	last := hwaddr.EUI64FromUint64(block.Prefix.Uint64() | hostBits)
+	return last[:hwaddr.EUI48Len]
+}
+
+// byteAligned reports whether a block is a whole number of leading octets.
+func byteAligned(block hwaddr.Block) bool {
+	return block.Bits > 0 && block.Bits%byteBits == 0
+}
@@ -0,0 +1,134 @@
+package acl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/acl"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func blocks(t *testing.T, prefixes ...string) []hwaddr.Block {
+	t.Helper()
+
+	result := make([]hwaddr.Block, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		block, err := hwaddr.ParseBlock(prefix)
+		require.NoError(t, err)
+		result = append(result, block)
+	}
+	return result
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	got := acl.Aggregate(blocks(t, "70B3D57", "001B21", "001B21F", "001B20", "001B22", "70B3D57ED"))
+
+	require.Len(t, got, 3)
+	assert.Equal(t, "00:1b:20:00:00:00", hwaddr.AsColon(acl.MAC(got[0])))
+	assert.Equal(t, 23, got[0].Bits)
+	assert.Equal(t, "00:1b:22:00:00:00", hwaddr.AsColon(acl.MAC(got[1])))
+	assert.Equal(t, 24, got[1].Bits)
+	assert.Equal(t, "70:b3:d5:70:00:00", hwaddr.AsColon(acl.MAC(got[2])))
+	assert.Equal(t, 28, got[2].Bits)
+
+	assert.Equal(t, "ff:ff:fe:00:00:00", hwaddr.AsColon(acl.Mask(got[0])))
+	assert.Equal(t, "00:1b:21:ff:ff:ff", hwaddr.AsColon(acl.Last(got[0])))
+}
+
+func TestAggregateChain(t *testing.T) {
+	t.Parallel()
+
+	got := acl.Aggregate(blocks(t, "001B20", "001B21", "001B22", "001B23"))
+
+	require.Len(t, got, 1)
+	assert.Equal(t, 22, got[0].Bits)
+}
+
+func TestRenderers(t *testing.T) {
+	t.Parallel()
+
+	list := acl.Aggregate(blocks(t, "000C29", "001B20", "001B21", "70B3D57ED"))
+
+	cases := []struct {
+		name     string
+		renderer acl.Renderer
+		opts     acl.Options
+		want     string
+	}{
+		{
+			name:     "cisco-mac-acl",
+			renderer: acl.CiscoMACACL,
+			opts:     acl.Options{Deny: false, Name: "VENDOR", Chain: ""},
+			want: `mac access-list extended VENDOR
+ permit 000c.2900.0000 0000.00ff.ffff any
+ permit 001b.2000.0000 0000.01ff.ffff any
+ permit 70b3.d57e.d000 0000.0000.0fff any
+`,
+		},
+		{
+			name:     "junos-filter",
+			renderer: acl.JunosFilter,
+			opts:     acl.Options{Deny: true, Name: "VENDOR", Chain: ""},
+			want: `set firewall family ethernet-switching filter VENDOR term VENDOR from source-mac-address 00:0c:29:00:00:00/24
+set firewall family ethernet-switching filter VENDOR term VENDOR from source-mac-address 00:1b:20:00:00:00/23
+set firewall family ethernet-switching filter VENDOR term VENDOR from source-mac-address 70:b3:d5:7e:d0:00/36
+set firewall family ethernet-switching filter VENDOR term VENDOR then discard
+`,
+		},
+		{
+			name:     "nftables",
+			renderer: acl.Nftables,
+			opts:     acl.Options{Deny: true, Name: "", Chain: ""},
+			want: `ether saddr & ff:ff:ff:00:00:00 == 00:0c:29:00:00:00 drop
+ether saddr & ff:ff:fe:00:00:00 == 00:1b:20:00:00:00 drop
+ether saddr & ff:ff:ff:ff:f0:00 == 70:b3:d5:7e:d0:00 drop
+`,
+		},
+		{
+			name:     "iptables",
+			renderer: acl.Iptables,
+			opts:     acl.Options{Deny: false, Name: "", Chain: "INPUT"},
+			want: `-A INPUT -m bpf --bytecode "5,32 0 0 4292870150,84 0 0 4294967040,21 0 1 796928,6 0 0 1,6 0 0 0" -j ACCEPT
+-A INPUT -m bpf --bytecode "5,32 0 0 4292870150,84 0 0 4294966784,21 0 1 1777664,6 0 0 1,6 0 0 0" -j ACCEPT
+-A INPUT -m bpf --bytecode "8,32 0 0 4292870150,84 0 0 4294967295,21 0 4 1890833790,40 0 0 4292870154,84 0 0 61440,21 0 1 53248,6 0 0 1,6 0 0 0" -j ACCEPT
+`,
+		},
+		{
+			name:     "ebtables",
+			renderer: acl.Ebtables,
+			opts:     acl.Options{Deny: true, Name: "", Chain: "FORWARD"},
+			want: `-A FORWARD -s 00:0c:29:00:00:00/ff:ff:ff:00:00:00 -j DROP
+-A FORWARD -s 00:1b:20:00:00:00/ff:ff:fe:00:00:00 -j DROP
+-A FORWARD -s 70:b3:d5:7e:d0:00/ff:ff:ff:ff:f0:00 -j DROP
+`,
+		},
+		{
+			name:     "wireshark-filter",
+			renderer: acl.WiresharkFilter,
+			opts:     acl.Options{Deny: false, Name: "", Chain: ""},
+			want: "eth.src[0:3] == 00:0c:29 || " +
+				"(eth.src >= 00:1b:20:00:00:00 && eth.src <= 00:1b:21:ff:ff:ff) || " +
+				"(eth.src >= 70:b3:d5:7e:d0:00 && eth.src <= 70:b3:d5:7e:df:ff)\n",
+		},
+		{
+			name:     "bpf",
+			renderer: acl.BPF,
+			opts:     acl.Options{Deny: false, Name: "", Chain: ""},
+			want: "(ether[6:4] & 0xffffff00 == 0x000c2900) or " +
+				"(ether[6:4] & 0xfffffe00 == 0x001b2000) or " +
+				"(ether[6:4] & 0xffffffff == 0x70b3d57e and ether[10:2] & 0xf000 == 0xd000)\n",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.renderer(list, tt.opts))
+		})
+	}
+}
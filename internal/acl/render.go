@@ -0,0 +1,237 @@
+package acl
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// Renderer returns an access list or a filter matching source MACs of blocks.
+type Renderer func(blocks []hwaddr.Block, opts Options) string
+
+/*
+CiscoMACACL renders an extended MAC access list of Cisco IOS/NX-OS with
+wildcard masks:
+
+	mac access-list extended VENDOR
+	 permit 001b.2100.0000 0000.00ff.ffff any
+*/
+func CiscoMACACL(blocks []hwaddr.Block, opts Options) string {
+	action := "permit"
+	if opts.Deny {
+		action = "deny"
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "mac access-list extended %s\n", opts.Name)
+	for _, block := range blocks {
+		fmt.Fprintf(&buf, " %s %s %s any\n", action, hwaddr.AsDot(MAC(block)), hwaddr.AsDot(wildcard(block)))
+	}
+
+	return buf.String()
+}
+
+/*
+JunosFilter renders set commands of an ethernet-switching firewall filter with
+a single term matching all the blocks:
+
+	set firewall family ethernet-switching filter VENDOR term VENDOR from source-mac-address 00:1b:21:00:00:00/24
+	set firewall family ethernet-switching filter VENDOR term VENDOR then accept
+*/
+func JunosFilter(blocks []hwaddr.Block, opts Options) string {
+	action := "accept"
+	if opts.Deny {
+		action = "discard"
+	}
+
+	term := fmt.Sprintf("set firewall family ethernet-switching filter %s term %s", opts.Name, opts.Name)
+
+	var buf strings.Builder
+	for _, block := range blocks {
+		fmt.Fprintf(&buf, "%s from source-mac-address %s/%d\n", term, hwaddr.AsColon(MAC(block)), block.Bits)
+	}
+	fmt.Fprintf(&buf, "%s then %s\n", term, action)
+
+	return buf.String()
+}
+
+/*
+Nftables renders rules of nftables to be put into a chain of a bridge, inet or
+netdev table:
+
+	ether saddr & ff:ff:ff:00:00:00 == 00:1b:21:00:00:00 accept
+*/
+func Nftables(blocks []hwaddr.Block, opts Options) string {
+	action := "accept"
+	if opts.Deny {
+		action = "drop"
+	}
+
+	var buf strings.Builder
+	for _, block := range blocks {
+		fmt.Fprintf(&buf, "ether saddr & %s == %s %s\n", hwaddr.AsColon(Mask(block)), hwaddr.AsColon(MAC(block)), action)
+	}
+
+	return buf.String()
+}
+
+/*
+Iptables renders rules in the iptables-restore format. The mac match of
+iptables takes no mask, so rules use the bpf match with a classic BPF program
+loading the source MAC relative to the link-layer header. The header is only
+available for packets received from Ethernet, i.e. in PREROUTING, INPUT and
+FORWARD:
+
+	-A INPUT -m bpf --bytecode "8,32 0 0 4292870150,..." -j ACCEPT
+*/
+func Iptables(blocks []hwaddr.Block, opts Options) string {
+	action := "ACCEPT"
+	if opts.Deny {
+		action = "DROP"
+	}
+
+	var buf strings.Builder
+	for _, block := range blocks {
+		fmt.Fprintf(&buf, "-A %s -m bpf --bytecode %q -j %s\n", opts.Chain, bpfBytecode(block), action)
+	}
+
+	return buf.String()
+}
+
+/*
+Ebtables renders rules of ebtables:
+
+	-A FORWARD -s 00:1b:21:00:00:00/ff:ff:ff:00:00:00 -j ACCEPT
+*/
+func Ebtables(blocks []hwaddr.Block, opts Options) string {
+	action := "ACCEPT"
+	if opts.Deny {
+		action = "DROP"
+	}
+
+	var buf strings.Builder
+	for _, block := range blocks {
+		fmt.Fprintf(&buf, "-A %s -s %s/%s -j %s\n",
+			opts.Chain, hwaddr.AsColon(MAC(block)), hwaddr.AsColon(Mask(block)), action)
+	}
+
+	return buf.String()
+}
+
+/*
+WiresharkFilter renders a display filter matching any of the blocks. Blocks of
+whole octets compare a slice of the address, others a range:
+
+	eth.src[0:3] == 00:1b:21 || (eth.src >= 70:b3:d5:70:00:00 && eth.src <= 70:b3:d5:7f:ff:ff)
+
+The filter matches regardless of the action.
+*/
+func WiresharkFilter(blocks []hwaddr.Block, _ Options) string {
+	terms := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if byteAligned(block) {
+			octets := block.Bits / byteBits
+			terms = append(terms, fmt.Sprintf("eth.src[0:%d] == %s", octets, hwaddr.AsColon(MAC(block)[:octets])))
+			continue
+		}
+		terms = append(terms, fmt.Sprintf("(eth.src >= %s && eth.src <= %s)",
+			hwaddr.AsColon(MAC(block)), hwaddr.AsColon(Last(block))))
+	}
+
+	return strings.Join(terms, " || ") + "\n"
+}
+
+/*
+BPF renders a pcap-filter expression for tcpdump and other libpcap based tools
+matching any of the blocks:
+
+	(ether[6:4] & 0xffffff00 == 0x001b2100)
+
+The filter matches regardless of the action.
+*/
+func BPF(blocks []hwaddr.Block, _ Options) string {
+	terms := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		hiMask, hiValue, loMask, loValue := splitMAC(block)
+		term := fmt.Sprintf("ether[6:4] & 0x%08x == 0x%08x", hiMask, hiValue)
+		if loMask != 0 {
+			term += fmt.Sprintf(" and ether[10:2] & 0x%04x == 0x%04x", loMask, loValue)
+		}
+		terms = append(terms, "("+term+")")
+	}
+
+	return strings.Join(terms, " or ") + "\n"
+}
+
+// wildcard returns the inverted mask of a block.
+func wildcard(block hwaddr.Block) []byte {
+	mask := Mask(block)
+	inverted := make([]byte, len(mask))
+	for i, b := range mask {
+		inverted[i] = ^b
+	}
+	return inverted
+}
+
+/*
+splitMAC returns the mask and the value of the first four octets and of the
+last two octets of a block, the way BPF loads the source MAC: a word at offset
+6 and a half word at offset 10 of an Ethernet header.
+*/
+//nolint: mnd // offsets are defined by the layout
+func splitMAC(block hwaddr.Block) (uint32, uint32, uint32, uint32) {
+	mac, mask := MAC(block), Mask(block)
+
+	hiMask := binary.BigEndian.Uint32(mask[:4])
+	loMask := uint32(binary.BigEndian.Uint16(mask[4:]))
+
+	return hiMask, binary.BigEndian.Uint32(mac[:4]) & hiMask, loMask, uint32(binary.BigEndian.Uint16(mac[4:])) & loMask
+}
+
+/*
+bpfBytecode compiles a classic BPF program matching the source MAC of a block
+in the format of the iptables bpf match: the number of instructions followed
+by "code jt jf k" of every instruction.
+*/
+func bpfBytecode(block hwaddr.Block) string {
+	const (
+		ldW   = 0x20       // BPF_LD | BPF_W | BPF_ABS
+		ldH   = 0x28       // BPF_LD | BPF_H | BPF_ABS
+		andK  = 0x54       // BPF_ALU | BPF_AND | BPF_K
+		jeqK  = 0x15       // BPF_JMP | BPF_JEQ | BPF_K
+		retK  = 0x06       // BPF_RET | BPF_K
+		llOff = 0xFFE00000 // SKF_LL_OFF, the start of the link-layer header
+		srcHi = llOff + 6
+		srcLo = llOff + 10
+	)
+
+	hiMask, hiValue, loMask, loValue := splitMAC(block)
+
+	var checkLo [][4]uint32
+	if loMask != 0 {
+		checkLo = [][4]uint32{
+			{ldH, 0, 0, srcLo},
+			{andK, 0, 0, loMask},
+			{jeqK, 0, 1, loValue},
+		}
+	}
+
+	// A mismatch of the first four octets jumps past the check of the last two.
+	program := [][4]uint32{
+		{ldW, 0, 0, srcHi},
+		{andK, 0, 0, hiMask},
+		{jeqK, 0, uint32(len(checkLo)) + 1, hiValue}, //nolint: gosec // a few instructions
+	}
+	program = append(program, checkLo...)
+	program = append(program, [4]uint32{retK, 0, 0, 1}, [4]uint32{retK, 0, 0, 0})
+
+	instructions := make([]string, 0, len(program)+1)
+	instructions = append(instructions, fmt.Sprint(len(program)))
+	for _, ins := range program {
+		instructions = append(instructions, fmt.Sprintf("%d %d %d %d", ins[0], ins[1], ins[2], ins[3]))
+	}
+
+	return strings.Join(instructions, ",")
+}
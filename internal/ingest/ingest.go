@@ -0,0 +1,342 @@
+/*
+Package ingest parses MAC/ARP tables printed by operating systems and network
+devices into structured entries. Parsers are lenient: lines that do not look
+like table entries (headers, summaries, incomplete entries) are skipped.
+*/
+package ingest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+const (
+	TypeDynamic = "dynamic"
+	TypeStatic  = "static"
+)
+
+// Entry is a row of a MAC or ARP table. Fields missing from a particular table
+// are left empty.
+type Entry struct {
+	MAC       []byte
+	IP        netip.Addr
+	VLAN      string
+	Interface string
+	Type      string
+}
+
+// Parser reads all entries of a table.
+type Parser func(r io.Reader) ([]Entry, error)
+
+// lineParser parses whitespace-separated fields of a line.
+type lineParser func(fields []string) (Entry, bool)
+
+func parseLines(r io.Reader, parse lineParser) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if entry, ok := parse(fields); ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading table: %w", err)
+	}
+
+	return entries, nil
+}
+
+func newEntry() Entry {
+	return Entry{MAC: nil, IP: netip.Addr{}, VLAN: "", Interface: "", Type: ""}
+}
+
+/*
+IPNeigh parses the output of 'ip neigh' on Linux:
+
+	192.168.1.1 dev eth0 lladdr 00:1b:21:0a:0b:0c REACHABLE
+*/
+func IPNeigh(r io.Reader) ([]Entry, error) {
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		entry := newEntry()
+
+		ip, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.IP = ip
+
+		for i := 1; i < len(fields)-1; i++ {
+			switch fields[i] {
+			case "dev":
+				entry.Interface = fields[i+1]
+			case "lladdr":
+				entry.MAC = parseMAC(fields[i+1])
+			}
+		}
+		if entry.MAC == nil {
+			return Entry{}, false
+		}
+		entry.Type = strings.ToLower(fields[len(fields)-1])
+
+		return entry, true
+	})
+}
+
+/*
+ARPBSD parses the output of 'arp -an' on BSD, macOS and Linux (net-tools).
+Leading zeros that macOS drops from octets are restored:
+
+	? (192.168.1.1) at 0:1b:21:a:b:c on en0 ifscope [ethernet]
+	? (10.0.0.1) at 00:1b:21:00:00:01 [ether] on eth1
+*/
+func ARPBSD(r io.Reader) ([]Entry, error) {
+	const minFields = 4
+
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		if len(fields) < minFields || fields[2] != "at" {
+			return Entry{}, false
+		}
+
+		entry := newEntry()
+
+		ip, err := netip.ParseAddr(strings.Trim(fields[1], "()"))
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.IP = ip
+
+		entry.MAC = parseMAC(fields[3])
+		if entry.MAC == nil {
+			return Entry{}, false
+		}
+
+		entry.Type = TypeDynamic
+		for i := 4; i < len(fields); i++ {
+			switch fields[i] {
+			case "on":
+				if i+1 < len(fields) {
+					entry.Interface = fields[i+1]
+				}
+			case "permanent", "PERM", "static":
+				entry.Type = TypeStatic
+			}
+		}
+
+		return entry, true
+	})
+}
+
+/*
+ARPWindows parses the output of 'arp -a' on Windows. The interface of an entry
+is the address of the local interface from the section header:
+
+	Interface: 192.168.1.10 --- 0x4
+	  Internet Address      Physical Address      Type
+	  192.168.1.1           00-1b-21-0a-0b-0c     dynamic
+*/
+func ARPWindows(r io.Reader) ([]Entry, error) {
+	const numFields = 3
+
+	var iface string
+
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		if fields[0] == "Interface:" && len(fields) > 1 {
+			iface = fields[1]
+			return Entry{}, false
+		}
+		if len(fields) != numFields {
+			return Entry{}, false
+		}
+
+		entry := newEntry()
+
+		ip, err := netip.ParseAddr(fields[0])
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.IP = ip
+
+		entry.MAC = parseMAC(fields[1])
+		if entry.MAC == nil {
+			return Entry{}, false
+		}
+		entry.Interface = iface
+		entry.Type = strings.ToLower(fields[2])
+
+		return entry, true
+	})
+}
+
+/*
+CiscoMACTable parses the output of 'show mac address-table' on Cisco IOS and
+NX-OS:
+
+	Vlan    Mac Address       Type        Ports
+	  10    001b.210a.0b0c    DYNAMIC     Gi1/0/1
+	* 30    0002.c900.0001    dynamic  0         F      F    Eth1/1
+*/
+func CiscoMACTable(r io.Reader) ([]Entry, error) {
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		return macTableEntry(fields, len(fields)-1)
+	})
+}
+
+/*
+AristaMACTable parses the output of 'show mac address-table' on Arista EOS:
+
+	Vlan    Mac Address       Type        Ports      Moves   Last Move
+	   1    001b.210a.0b0c    DYNAMIC     Et1        1       0:00:10 ago
+*/
+func AristaMACTable(r io.Reader) ([]Entry, error) {
+	const portOffset = 2
+
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		i := macIndex(fields)
+		if i < 0 {
+			return Entry{}, false
+		}
+		return macTableEntry(fields, i+portOffset)
+	})
+}
+
+// macTableEntry parses a row of "vlan mac type ... port" with the port at a
+// given index.
+func macTableEntry(fields []string, port int) (Entry, bool) {
+	i := macIndex(fields)
+	if i < 1 || i+1 >= len(fields) || port <= i+1 || port >= len(fields) {
+		return Entry{}, false
+	}
+
+	entry := newEntry()
+	entry.MAC = parseMAC(fields[i])
+	entry.VLAN = fields[i-1]
+	entry.Type = strings.ToLower(fields[i+1])
+	entry.Interface = fields[port]
+
+	return entry, true
+}
+
+/*
+CiscoARP parses the output of 'show ip arp' on Cisco IOS. Entries without an
+age are addresses of the device itself and reported as static, the VLAN is
+taken from SVI names:
+
+	Protocol  Address          Age (min)  Hardware Addr   Type   Interface
+	Internet  10.0.10.1               -   001b.210a.0b0c  ARPA   Vlan10
+*/
+func CiscoARP(r io.Reader) ([]Entry, error) {
+	const numFields = 6
+
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		if len(fields) != numFields || fields[0] != "Internet" {
+			return Entry{}, false
+		}
+
+		entry := newEntry()
+
+		ip, err := netip.ParseAddr(fields[1])
+		if err != nil {
+			return Entry{}, false
+		}
+		entry.IP = ip
+
+		entry.MAC = parseMAC(fields[3])
+		if entry.MAC == nil {
+			return Entry{}, false
+		}
+
+		entry.Type = TypeDynamic
+		if fields[2] == "-" {
+			entry.Type = TypeStatic
+		}
+		entry.Interface = fields[5]
+		if vlan, ok := strings.CutPrefix(entry.Interface, "Vlan"); ok {
+			entry.VLAN = vlan
+		}
+
+		return entry, true
+	})
+}
+
+/*
+JunosEthernetSwitching parses the output of 'show ethernet-switching table' on
+Junos, both ELS and legacy formats:
+
+	Vlan    MAC                 MAC      Age    Logical       NH     RTR
+	v10     00:1b:21:0a:0b:0c   D          -    ge-0/0/1.0    0      0
+
+	VLAN              MAC address       Type         Age Interfaces
+	default           00:1b:21:00:00:01 Learn          0 ge-0/0/3.0
+*/
+func JunosEthernetSwitching(r io.Reader) ([]Entry, error) {
+	const ifaceOffset = 3
+
+	return parseLines(r, func(fields []string) (Entry, bool) {
+		i := macIndex(fields)
+		if i < 1 || i+ifaceOffset >= len(fields) {
+			return Entry{}, false
+		}
+
+		entry := newEntry()
+		entry.MAC = parseMAC(fields[i])
+		entry.VLAN = fields[i-1]
+		entry.Interface = fields[i+ifaceOffset]
+
+		switch flags := fields[i+1]; {
+		case flags == "Learn" || strings.Contains(flags, "D"):
+			entry.Type = TypeDynamic
+		case flags == "Static" || strings.Contains(flags, "S") || strings.Contains(flags, "P"):
+			entry.Type = TypeStatic
+		default:
+			entry.Type = strings.ToLower(flags)
+		}
+
+		return entry, true
+	})
+}
+
+// macIndex returns the index of the first field that is a MAC or -1.
+func macIndex(fields []string) int {
+	for i, field := range fields {
+		if parseMAC(field) != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseMAC returns nil if s is not a MAC. Octets without leading zeros are
+// accepted in the colon notation.
+func parseMAC(s string) []byte {
+	if addr, err := hwaddr.ParseAddr(s); err == nil {
+		return addr
+	}
+
+	octets := strings.Split(s, ":")
+	if len(octets) != hwaddr.EUI48Len {
+		return nil
+	}
+	for i, octet := range octets {
+		if len(octet) == 1 {
+			octets[i] = "0" + octet
+		}
+	}
+
+	addr, err := hwaddr.ParseAddr(strings.Join(octets, ":"))
+	if err != nil {
+		return nil
+	}
+
+	return addr
+}
@@ -0,0 +1,142 @@
+package ingest_test
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/ingest"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type row struct {
+	mac, ip, vlan, iface, typ string
+}
+
+func TestParsers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fixture string
+		parser  ingest.Parser
+		want    []row
+	}{
+		{
+			fixture: "ip-neigh.txt",
+			parser:  ingest.IPNeigh,
+			want: []row{
+				{"00:1b:21:0a:0b:0c", "192.168.1.1", "", "eth0", "reachable"},
+				{"00:0c:29:aa:bb:cc", "192.168.1.23", "", "eth0", "stale"},
+				{"02:42:ac:11:00:02", "10.10.0.7", "", "br-lan", "delay"},
+				{"00:1b:21:0a:0b:0c", "fe80::1", "", "eth0", "reachable"},
+			},
+		},
+		{
+			fixture: "arp-bsd.txt",
+			parser:  ingest.ARPBSD,
+			want: []row{
+				{"00:1b:21:0a:0b:0c", "192.168.1.1", "", "en0", "dynamic"},
+				{"00:0c:29:aa:bb:cc", "192.168.1.23", "", "en0", "static"},
+				{"01:00:5e:00:00:fb", "224.0.0.251", "", "en0", "static"},
+				{"00:1b:21:00:00:01", "10.0.0.1", "", "eth1", "dynamic"},
+			},
+		},
+		{
+			fixture: "arp-windows.txt",
+			parser:  ingest.ARPWindows,
+			want: []row{
+				{"00:1b:21:0a:0b:0c", "192.168.1.1", "", "192.168.1.10", "dynamic"},
+				{"00:0c:29:aa:bb:cc", "192.168.1.23", "", "192.168.1.10", "dynamic"},
+				{"ff:ff:ff:ff:ff:ff", "192.168.1.255", "", "192.168.1.10", "static"},
+				{"01:00:5e:00:00:16", "224.0.0.22", "", "192.168.1.10", "static"},
+				{"00:1b:21:00:00:01", "10.0.0.1", "", "10.0.0.5", "dynamic"},
+			},
+		},
+		{
+			fixture: "cisco-mac-table.txt",
+			parser:  ingest.CiscoMACTable,
+			want: []row{
+				{"01:00:0c:cc:cc:cc", "", "All", "CPU", "static"},
+				{"01:00:0c:cc:cc:cd", "", "All", "CPU", "static"},
+				{"00:1b:21:0a:0b:0c", "", "10", "Gi1/0/1", "dynamic"},
+				{"00:0c:29:aa:bb:cc", "", "20", "Gi1/0/2", "dynamic"},
+				{"00:02:c9:00:00:01", "", "30", "Eth1/1", "dynamic"},
+			},
+		},
+		{
+			fixture: "cisco-arp.txt",
+			parser:  ingest.CiscoARP,
+			want: []row{
+				{"00:1b:21:0a:0b:0c", "10.0.10.1", "10", "Vlan10", "static"},
+				{"00:0c:29:aa:bb:cc", "10.0.10.23", "10", "Vlan10", "dynamic"},
+				{"00:02:c9:00:00:01", "192.0.2.1", "", "GigabitEthernet0/1", "dynamic"},
+			},
+		},
+		{
+			fixture: "junos-ethernet-switching.txt",
+			parser:  ingest.JunosEthernetSwitching,
+			want: []row{
+				{"00:1b:21:0a:0b:0c", "", "v10", "ge-0/0/1.0", "dynamic"},
+				{"00:0c:29:aa:bb:cc", "", "v10", "ge-0/0/2.0", "dynamic"},
+				{"00:02:c9:00:00:01", "", "v20", "ae0.0", "static"},
+				{"00:1b:21:00:00:01", "", "default", "ge-0/0/3.0", "dynamic"},
+			},
+		},
+		{
+			fixture: "arista-mac-table.txt",
+			parser:  ingest.AristaMACTable,
+			want: []row{
+				{"00:1b:21:0a:0b:0c", "", "1", "Et1", "dynamic"},
+				{"00:0c:29:aa:bb:cc", "", "10", "Et2", "dynamic"},
+				{"00:02:c9:00:00:01", "", "10", "Po1", "static"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.fixture, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filepath.Join("testdata", tt.fixture))
+			require.NoError(t, err)
+			defer f.Close()
+
+			entries, err := tt.parser(f)
+			require.NoError(t, err)
+
+			got := make([]row, 0, len(entries))
+			for _, e := range entries {
+				ip := ""
+				if e.IP.IsValid() {
+					ip = e.IP.String()
+				}
+				got = append(got, row{hwaddr.AsColon(e.MAC), ip, e.VLAN, e.Interface, e.Type})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsersSkipUnrelatedText(t *testing.T) {
+	t.Parallel()
+
+	entries, err := ingest.CiscoARP(strings.NewReader("show version\nInternet  10.0.0.1  -  Incomplete  ARPA  Vlan1\n"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = ingest.CiscoMACTable(strings.NewReader("Total Mac Addresses for this criterion: 5\n"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// An entry outside of any interface section.
+	entries, err = ingest.ARPWindows(strings.NewReader("  10.0.0.1  00-1b-21-00-00-01  dynamic\n"))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), entries[0].IP)
+	assert.Empty(t, entries[0].Interface)
+}
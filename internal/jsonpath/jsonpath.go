@@ -0,0 +1,246 @@
+/*
+Package jsonpath reads and writes values of JSON objects addressed by a path
+like .src.mac. Objects are edited in place: key order, unknown fields and the
+formatting of values that are not on the path are preserved.
+*/
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPath = errors.New("invalid path")
+	ErrNotFound    = errors.New("path not found")
+	ErrNotObject   = errors.New("not a JSON object")
+)
+
+// Path is a sequence of object keys.
+type Path []string
+
+/*
+Parse parses a path of dot-separated keys like .src.mac. The leading dot is
+optional. Keys containing dots or other special characters are written as
+quoted JSON strings: ."source.mac".
+*/
+func Parse(s string) (Path, error) {
+	var path Path
+
+	rest := strings.TrimPrefix(s, ".")
+	if rest == "" {
+		return nil, fmt.Errorf("%w %q: empty", ErrInvalidPath, s)
+	}
+
+	for {
+		var key string
+
+		if strings.HasPrefix(rest, `"`) {
+			end := quotedLen(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("%w %q: unterminated quoted key", ErrInvalidPath, s)
+			}
+			var err error
+			if key, err = strconv.Unquote(rest[:end]); err != nil {
+				return nil, fmt.Errorf("%w %q: %w", ErrInvalidPath, s, err)
+			}
+			rest = rest[end:]
+			if rest != "" && rest[0] != '.' {
+				return nil, fmt.Errorf("%w %q: expected a dot after a quoted key", ErrInvalidPath, s)
+			}
+		} else {
+			end := strings.IndexByte(rest, '.')
+			if end < 0 {
+				end = len(rest)
+			}
+			key, rest = rest[:end], rest[end:]
+			if key == "" {
+				return nil, fmt.Errorf("%w %q: empty key", ErrInvalidPath, s)
+			}
+		}
+
+		path = append(path, key)
+
+		if rest == "" {
+			return path, nil
+		}
+		rest = rest[1:]
+	}
+}
+
+// String formats a path the way [Parse] accepts it.
+func (p Path) String() string {
+	var buf strings.Builder
+	for _, key := range p {
+		buf.WriteByte('.')
+		if key == "" || strings.ContainsAny(key, `."\ `) {
+			buf.WriteString(strconv.Quote(key))
+		} else {
+			buf.WriteString(key)
+		}
+	}
+	return buf.String()
+}
+
+// Sibling returns a path to a key next to the last key of a path.
+func (p Path) Sibling(key string) Path {
+	if len(p) == 0 {
+		return Path{key}
+	}
+	r := make(Path, len(p))
+	copy(r, p)
+	r[len(r)-1] = key
+	return r
+}
+
+// Get returns the raw value at a path of a JSON object.
+func Get(doc []byte, path Path) (json.RawMessage, error) {
+	value := json.RawMessage(doc)
+
+	for i, key := range path {
+		members, err := parseObject(value)
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", path[:i], err)
+		}
+		idx := index(members, key)
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, path[:i+1])
+		}
+		value = members[idx].value
+	}
+
+	return value, nil
+}
+
+/*
+Set returns a copy of a JSON object with a value at a path replaced. Missing
+keys are appended to their objects, missing intermediate objects are created.
+*/
+func Set(doc []byte, path Path, value json.RawMessage) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidPath)
+	}
+
+	members, err := parseObject(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	newValue := value
+	idx := index(members, path[0])
+
+	if len(path) > 1 {
+		child := json.RawMessage("{}")
+		if idx >= 0 {
+			child = members[idx].value
+		}
+		newValue, err = Set(child, path[1:], value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", Path{path[0]}, err)
+		}
+	}
+
+	if idx >= 0 {
+		members[idx].value = newValue
+	} else {
+		members = append(members, member{key: path[0], value: newValue})
+	}
+
+	return formatObject(members)
+}
+
+type member struct {
+	key   string
+	value json.RawMessage
+}
+
+func index(members []member, key string) int {
+	for i, m := range members {
+		if m.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func parseObject(doc []byte) ([]member, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotObject, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, ErrNotObject
+	}
+
+	var members []member
+
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrNotObject, err)
+		}
+		key, _ := tok.(string)
+
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrNotObject, err)
+		}
+		members = append(members, member{key: key, value: value})
+	}
+
+	if _, err = dec.Token(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotObject, err)
+	}
+	if _, err = dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: trailing data", ErrNotObject)
+	}
+
+	return members, nil
+}
+
+func formatObject(members []member) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := enc.Encode(m.key); err != nil {
+			return nil, fmt.Errorf("encoding key %q: %w", m.key, err)
+		}
+		// Encode terminates every value with a newline.
+		buf.Truncate(buf.Len() - 1)
+		buf.WriteByte(':')
+		buf.Write(m.value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// quotedLen returns the length of a JSON string at the start of s or -1.
+func quotedLen(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,123 @@
+package jsonpath_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/jsonpath"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		want  jsonpath.Path
+	}{
+		{".mac", jsonpath.Path{"mac"}},
+		{"mac", jsonpath.Path{"mac"}},
+		{".src.mac", jsonpath.Path{"src", "mac"}},
+		{`."source.mac"`, jsonpath.Path{"source.mac"}},
+		{`.event."src mac".value`, jsonpath.Path{"event", "src mac", "value"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := jsonpath.Parse(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			again, err := jsonpath.Parse(got.String())
+			require.NoError(t, err)
+			assert.Equal(t, got, again)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", ".", "..mac", ".src.", `."mac`, `."mac"x`} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := jsonpath.Parse(input)
+			require.ErrorIs(t, err, jsonpath.ErrInvalidPath)
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`{"ts": 1, "src": {"ip": "192.0.2.1", "mac": "00aa.11bb.22cc"}, "tags": ["a"]}`)
+
+	got, err := jsonpath.Get(doc, jsonpath.Path{"src", "mac"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `"00aa.11bb.22cc"`, string(got))
+
+	got, err = jsonpath.Get(doc, jsonpath.Path{"tags"})
+	require.NoError(t, err)
+	assert.Equal(t, `["a"]`, string(got))
+
+	_, err = jsonpath.Get(doc, jsonpath.Path{"dst", "mac"})
+	require.ErrorIs(t, err, jsonpath.ErrNotFound)
+
+	_, err = jsonpath.Get(doc, jsonpath.Path{"ts", "mac"})
+	require.ErrorIs(t, err, jsonpath.ErrNotObject)
+
+	_, err = jsonpath.Get([]byte(`["a"]`), jsonpath.Path{"mac"})
+	require.ErrorIs(t, err, jsonpath.ErrNotObject)
+
+	_, err = jsonpath.Get([]byte(`{"mac": "a"} x`), jsonpath.Path{"mac"})
+	require.ErrorIs(t, err, jsonpath.ErrNotObject)
+}
+
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	doc := []byte(`{"z": 1, "src": {"mac": "00aa.11bb.22cc", "a": [1, 2]}, "b": "<x>"}`)
+
+	cases := []struct {
+		name  string
+		path  jsonpath.Path
+		value string
+		want  string
+	}{
+		{
+			name:  "replace",
+			path:  jsonpath.Path{"src", "mac"},
+			value: `"00:aa:11:bb:22:cc"`,
+			want:  `{"z":1,"src":{"mac":"00:aa:11:bb:22:cc","a":[1, 2]},"b":"<x>"}`,
+		},
+		{
+			name:  "append",
+			path:  jsonpath.Path{"src", "vendor"},
+			value: `"ACME"`,
+			want:  `{"z":1,"src":{"mac":"00aa.11bb.22cc","a":[1, 2],"vendor":"ACME"},"b":"<x>"}`,
+		},
+		{
+			name:  "create intermediate",
+			path:  jsonpath.Path{"enrich", "vendor"},
+			value: `null`,
+			want:  `{"z":1,"src":{"mac": "00aa.11bb.22cc", "a": [1, 2]},"b":"<x>","enrich":{"vendor":null}}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := jsonpath.Set(doc, tt.path, []byte(tt.value))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := jsonpath.Set(doc, jsonpath.Path{"z", "vendor"}, []byte(`"ACME"`))
+	require.ErrorIs(t, err, jsonpath.ErrNotObject)
+}
@@ -0,0 +1,341 @@
+package leases
+
+import (
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// iscToken is a word, a quoted string with escapes decoded or one of "{", "}"
+// and ";".
+type iscToken struct {
+	text   string
+	quoted bool
+}
+
+// iscStatement is a list of tokens terminated by ";" or followed by a block.
+type iscStatement struct {
+	args  []iscToken
+	block []iscStatement
+}
+
+func (s iscStatement) arg(i int) string {
+	if i < len(s.args) {
+		return s.args[i].text
+	}
+	return ""
+}
+
+/*
+ISCDhcpd parses dhcpd.leases of ISC dhcpd, both DHCPv4 leases and DHCPv6
+identity associations:
+
+	lease 192.168.1.10 {
+	  ends 4 2023/11/16 22:00:00;
+	  binding state active;
+	  hardware ethernet 00:1b:21:0a:0b:0c;
+	  uid "\001\000\033!\012\013\014";
+	  client-hostname "laptop";
+	}
+	ia-na "\001\002\003\004\000\001\000\001..." {
+	  iaaddr 2001:db8::10 {
+	    ends 4 2023/11/16 22:00:00;
+	  }
+	}
+
+The identifier of an identity association is the IAID followed by the DUID.
+*/
+func ISCDhcpd(r io.Reader) ([]Lease, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading leases: %w", err)
+	}
+
+	tokens, err := iscTokens(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var pos int
+	statements, err := iscParse(tokens, &pos, false)
+	if err != nil {
+		return nil, err
+	}
+
+	leases := newHistory()
+
+	for _, statement := range statements {
+		switch statement.arg(0) {
+		case "lease":
+			if lease, ok := iscLease(statement); ok {
+				leases.set(lease)
+			}
+		case "ia-na", "ia-ta", "ia-pd":
+			for _, lease := range iscIdentityAssociation(statement) {
+				leases.set(lease)
+			}
+		}
+	}
+
+	return leases.list(), nil
+}
+
+func iscLease(statement iscStatement) (Lease, bool) {
+	const hardwareEthernet = "ethernet"
+
+	lease := newLease()
+
+	ip, err := netip.ParseAddr(statement.arg(1))
+	if err != nil {
+		return Lease{}, false
+	}
+	lease.IP = ip
+
+	for _, field := range statement.block {
+		switch field.arg(0) {
+		case "hardware":
+			if field.arg(1) == hardwareEthernet {
+				lease.MAC, _ = hwaddr.ParseAddr(field.arg(2))
+			}
+		case "uid":
+			if len(field.args) > 1 {
+				lease.ClientID = iscBytes(field.args[1])
+			}
+		case "client-hostname":
+			lease.Hostname = field.arg(1)
+		default:
+			iscCommon(&lease, field)
+		}
+	}
+	if lease.MAC == nil {
+		lease.MAC = clientIDMAC(lease.ClientID)
+	}
+
+	return lease, true
+}
+
+func iscIdentityAssociation(statement iscStatement) []Lease {
+	const iaidLen = 4
+
+	if len(statement.args) < 2 {
+		return nil
+	}
+	id := iscBytes(statement.args[1])
+	if len(id) <= iaidLen {
+		return nil
+	}
+	duid := id[iaidLen:]
+
+	var leases []Lease
+
+	for _, field := range statement.block {
+		lease := newLease()
+		lease.ClientID = duid
+		lease.MAC = duidMAC(duid)
+
+		switch field.arg(0) {
+		case "iaaddr":
+			ip, err := netip.ParseAddr(field.arg(1))
+			if err != nil {
+				continue
+			}
+			lease.IP = ip
+		case "iaprefix":
+			prefix, err := netip.ParsePrefix(field.arg(1))
+			if err != nil {
+				continue
+			}
+			lease.IP = prefix.Addr()
+			lease.PrefixLen = prefix.Bits()
+		default:
+			continue
+		}
+
+		for _, sub := range field.block {
+			iscCommon(&lease, sub)
+		}
+		leases = append(leases, lease)
+	}
+
+	return leases
+}
+
+// iscCommon handles statements shared by leases and IA addresses.
+func iscCommon(lease *Lease, field iscStatement) {
+	switch {
+	case field.arg(0) == "ends":
+		lease.Expiry = iscTime(field.args[1:])
+	case field.arg(0) == "binding" && field.arg(1) == "state":
+		lease.State = field.arg(2)
+	}
+}
+
+/*
+iscTime parses "never", "epoch <seconds>" and "<weekday> <yyyy/mm/dd>
+<hh:mm:ss>" in UTC. Unparsable times are treated as "never".
+*/
+func iscTime(args []iscToken) time.Time {
+	const (
+		dateTimeArgs   = 3
+		dateTimeLayout = "2006/01/02 15:04:05"
+	)
+
+	switch {
+	case len(args) == 2 && args[0].text == "epoch":
+		sec, err := strconv.ParseInt(args[1].text, 10, 64)
+		if err != nil {
+			return time.Time{}
+		}
+		return time.Unix(sec, 0).UTC()
+	case len(args) == dateTimeArgs:
+		ts, err := time.Parse(dateTimeLayout, args[1].text+" "+args[2].text)
+		if err != nil {
+			return time.Time{}
+		}
+		return ts
+	default:
+		return time.Time{}
+	}
+}
+
+// iscBytes returns quoted strings as they are and decodes unquoted hex.
+func iscBytes(token iscToken) []byte {
+	if token.quoted {
+		return []byte(token.text)
+	}
+	data, _ := parseHex(token.text)
+	return data
+}
+
+func iscTokens(data []byte) ([]iscToken, error) {
+	var tokens []iscToken
+
+	for i := 0; i < len(data); {
+		switch c := data[i]; {
+		case c == '#':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '{' || c == '}' || c == ';':
+			tokens = append(tokens, iscToken{text: string(c), quoted: false})
+			i++
+		case c == '"':
+			text, n, err := iscString(data[i:])
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, iscToken{text: text, quoted: true})
+			i += n
+		default:
+			start := i
+			for i < len(data) && !isISCDelimiter(data[i]) {
+				i++
+			}
+			tokens = append(tokens, iscToken{text: string(data[start:i]), quoted: false})
+		}
+	}
+
+	return tokens, nil
+}
+
+func isISCDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '{', '}', ';', '"', '#':
+		return true
+	default:
+		return false
+	}
+}
+
+// iscString decodes a quoted string with C escapes, octal ones included, and
+// returns the number of bytes consumed.
+func iscString(data []byte) (string, int, error) {
+	const (
+		maxOctalDigits = 3
+		octalBase      = 8
+	)
+
+	var buf []byte
+
+	for i := 1; i < len(data); i++ {
+		switch c := data[i]; c {
+		case '"':
+			return string(buf), i + 1, nil
+		case '\\':
+			i++
+			if i == len(data) {
+				break
+			}
+			switch e := data[i]; {
+			case e >= '0' && e <= '7':
+				var n int
+				for j := 0; j < maxOctalDigits && i < len(data) && data[i] >= '0' && data[i] <= '7'; j++ {
+					n = n*octalBase + int(data[i]-'0')
+					i++
+				}
+				i--
+				buf = append(buf, byte(n))
+			case e == 'n':
+				buf = append(buf, '\n')
+			case e == 't':
+				buf = append(buf, '\t')
+			case e == 'r':
+				buf = append(buf, '\r')
+			default:
+				buf = append(buf, e)
+			}
+		default:
+			buf = append(buf, c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string", ErrSyntax)
+}
+
+func iscParse(tokens []iscToken, pos *int, nested bool) ([]iscStatement, error) {
+	var (
+		statements []iscStatement
+		args       []iscToken
+	)
+
+	for *pos < len(tokens) {
+		token := tokens[*pos]
+		*pos++
+
+		switch {
+		case token.quoted:
+			args = append(args, token)
+		case token.text == ";":
+			if len(args) > 0 {
+				statements = append(statements, iscStatement{args: args, block: nil})
+			}
+			args = nil
+		case token.text == "{":
+			block, err := iscParse(tokens, pos, true)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, iscStatement{args: args, block: block})
+			args = nil
+		case token.text == "}":
+			if !nested || len(args) > 0 {
+				return nil, fmt.Errorf("%w: unexpected \"}\"", ErrSyntax)
+			}
+			return statements, nil
+		default:
+			args = append(args, token)
+		}
+	}
+
+	if nested || len(args) > 0 {
+		return nil, fmt.Errorf("%w: unexpected end of file", ErrSyntax)
+	}
+
+	return statements, nil
+}
@@ -0,0 +1,330 @@
+/*
+Package leases parses lease databases of DHCP servers: ISC dhcpd, dnsmasq and
+Kea. Lease files keep a history of updates, the most recent entry of an address
+wins.
+*/
+package leases
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+const StateActive = "active"
+
+var ErrSyntax = errors.New("syntax error")
+
+// Lease is an address assigned to a client. Fields missing from a particular
+// database are left empty.
+type Lease struct {
+	IP netip.Addr
+	// Length of a delegated prefix, zero for addresses.
+	PrefixLen int
+	// Hardware address of the client, from the client identifier or the DUID
+	// when the database does not record it.
+	MAC      []byte
+	Hostname string
+	// DHCPv4 client identifier or DHCPv6 DUID.
+	ClientID []byte
+	// Zero for leases that never expire.
+	Expiry time.Time
+	State  string
+}
+
+// Parser reads all leases of a database.
+type Parser func(r io.Reader) ([]Lease, error)
+
+func newLease() Lease {
+	return Lease{
+		IP:        netip.Addr{},
+		PrefixLen: 0,
+		MAC:       nil,
+		Hostname:  "",
+		ClientID:  nil,
+		Expiry:    time.Time{},
+		State:     "",
+	}
+}
+
+// history keeps the latest lease of every address in the order addresses first
+// appeared.
+type history struct {
+	order  []string
+	leases map[string]Lease
+}
+
+func newHistory() *history {
+	return &history{order: nil, leases: make(map[string]Lease)}
+}
+
+func leaseKey(lease Lease) string {
+	return lease.IP.String() + "/" + strconv.Itoa(lease.PrefixLen)
+}
+
+func (h *history) set(lease Lease) {
+	key := leaseKey(lease)
+	if _, ok := h.leases[key]; !ok {
+		h.order = append(h.order, key)
+	}
+	h.leases[key] = lease
+}
+
+func (h *history) remove(lease Lease) {
+	delete(h.leases, leaseKey(lease))
+}
+
+func (h *history) list() []Lease {
+	result := make([]Lease, 0, len(h.leases))
+	seen := make(map[string]bool, len(h.leases))
+	for _, key := range h.order {
+		lease, ok := h.leases[key]
+		if !ok || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, lease)
+	}
+	return result
+}
+
+/*
+Dnsmasq parses the lease file of dnsmasq. DHCPv4 lines hold the expiry, MAC,
+IP, hostname and client identifier, DHCPv6 lines have the IAID instead of the
+MAC and the DUID of the client in place of the client identifier:
+
+	1700136000 00:1b:21:0a:0b:0c 192.168.1.10 laptop 01:00:1b:21:0a:0b:0c
+	duid 00:01:00:01:2c:9d:18:31:00:1b:21:0a:0b:01
+	1700136000 16909060 2001:db8::10 * 00:01:00:01:2c:9d:18:31:00:02:c9:00:00:01
+
+An expiry of zero means an infinite lease, "*" an unknown field.
+*/
+func Dnsmasq(r io.Reader) ([]Lease, error) {
+	const numFields = 5
+
+	var leases []Lease
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != numFields {
+			continue
+		}
+
+		lease := newLease()
+
+		expiry, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		if expiry != 0 {
+			lease.Expiry = time.Unix(expiry, 0).UTC()
+		}
+
+		lease.IP, err = netip.ParseAddr(fields[2])
+		if err != nil {
+			continue
+		}
+		if fields[3] != "*" {
+			lease.Hostname = fields[3]
+		}
+		if fields[4] != "*" {
+			lease.ClientID, _ = parseHex(fields[4])
+		}
+		lease.State = StateActive
+
+		if lease.IP.Is4() {
+			lease.MAC, _ = hwaddr.ParseAddr(fields[1])
+			if lease.MAC == nil {
+				lease.MAC = clientIDMAC(lease.ClientID)
+			}
+		} else {
+			lease.MAC = duidMAC(lease.ClientID)
+		}
+
+		leases = append(leases, lease)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading leases: %w", err)
+	}
+
+	return leases, nil
+}
+
+// KeaMemfile parses a lease file of the Kea memfile backend, either DHCPv4 or
+// DHCPv6. Leases with a zero valid lifetime are deleted ones.
+func KeaMemfile(r io.Reader) ([]Lease, error) {
+	return parseKea(r, true)
+}
+
+/*
+KeaCSV parses leases exported from Kea as CSV, e.g. by 'kea-admin lease-dump'.
+Every row is a lease, hardware addresses and identifiers are hex with or
+without colons, the expiry is Unix time or "YYYY-MM-DD HH:MM:SS" in UTC.
+*/
+func KeaCSV(r io.Reader) ([]Lease, error) {
+	return parseKea(r, false)
+}
+
+// Kea lease states.
+var keaStates = map[string]string{ //nolint: gochecknoglobals // lookup table
+	"0": StateActive,
+	"1": "declined",
+	"2": "expired-reclaimed",
+}
+
+func parseKea(r io.Reader, memfile bool) ([]Lease, error) {
+	const (
+		leaseTypePrefix  = "2"
+		infiniteLifetime = math.MaxUint32
+		dateTimeLayout   = "2006-01-02 15:04:05"
+	)
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["address"]; !ok {
+		return nil, fmt.Errorf("%w: no address column in %q", ErrSyntax, strings.Join(header, ","))
+	}
+	_, isV6 := columns["duid"]
+
+	var all []Lease
+	latest := newHistory()
+
+	for {
+		record, rerr := reader.Read()
+		if errors.Is(rerr, io.EOF) {
+			break
+		}
+		if rerr != nil {
+			return nil, fmt.Errorf("reading leases: %w", rerr)
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+
+		lease := newLease()
+
+		lease.IP, err = netip.ParseAddr(get("address"))
+		if err != nil {
+			continue
+		}
+		if get("lease_type") == leaseTypePrefix {
+			lease.PrefixLen, _ = strconv.Atoi(get("prefix_len"))
+		}
+
+		lease.Hostname = strings.ReplaceAll(get("hostname"), "&#x2c", ",")
+		lease.State = keaStates[get("state")]
+
+		if mac, ok := parseHex(get("hwaddr")); ok && (len(mac) == hwaddr.EUI48Len || len(mac) == hwaddr.EUI64Len) {
+			lease.MAC = mac
+		}
+		if isV6 {
+			lease.ClientID, _ = parseHex(get("duid"))
+			if lease.MAC == nil {
+				lease.MAC = duidMAC(lease.ClientID)
+			}
+		} else {
+			lease.ClientID, _ = parseHex(get("client_id"))
+			if lease.MAC == nil {
+				lease.MAC = clientIDMAC(lease.ClientID)
+			}
+		}
+
+		lifetime, _ := strconv.ParseUint(get("valid_lifetime"), 10, 64)
+		if lifetime != infiniteLifetime {
+			expire := get("expire")
+			if sec, perr := strconv.ParseInt(expire, 10, 64); perr == nil {
+				lease.Expiry = time.Unix(sec, 0).UTC()
+			} else if ts, terr := time.Parse(dateTimeLayout, expire); terr == nil {
+				lease.Expiry = ts
+			}
+		}
+
+		switch {
+		case !memfile:
+			all = append(all, lease)
+		case lifetime == 0:
+			latest.remove(lease)
+		default:
+			latest.set(lease)
+		}
+	}
+
+	if !memfile {
+		return all, nil
+	}
+	return latest.list(), nil
+}
+
+// clientIDMAC extracts the MAC from a DHCPv4 client identifier of the Ethernet
+// type or a DUID based one (RFC 4361).
+func clientIDMAC(id []byte) []byte {
+	const (
+		typeEthernet = 1
+		typeDUID     = 255
+		iaidLen      = 4
+	)
+
+	switch {
+	case len(id) == 1+hwaddr.EUI48Len && id[0] == typeEthernet:
+		return id[1:]
+	case len(id) > 1+iaidLen && id[0] == typeDUID:
+		return duidMAC(id[1+iaidLen:])
+	default:
+		return nil
+	}
+}
+
+// duidMAC extracts the EUI of a DUID-LLT or a DUID-LL.
+func duidMAC(duid []byte) []byte {
+	mac, _ := hwaddr.DUID(duid).EUI()
+	return mac
+}
+
+// parseHex decodes hex strings with or without colons, octets may lack the
+// leading zero: "1:0:1b:21:a:b:c".
+func parseHex(s string) ([]byte, bool) {
+	if s == "" {
+		return nil, false
+	}
+	if !strings.Contains(s, ":") {
+		data, err := hex.DecodeString(s)
+		return data, err == nil
+	}
+
+	octets := strings.Split(s, ":")
+	data := make([]byte, 0, len(octets))
+	for _, octet := range octets {
+		b, err := strconv.ParseUint(octet, 16, 8)
+		if err != nil {
+			return nil, false
+		}
+		data = append(data, byte(b))
+	}
+
+	return data, true
+}
@@ -0,0 +1,139 @@
+package leases_test
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/leases"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type row struct {
+	ip, mac, hostname, clientID, expiry, state string
+}
+
+func toRows(list []leases.Lease) []row {
+	rows := make([]row, 0, len(list))
+	for _, l := range list {
+		r := row{ip: l.IP.String(), mac: "", hostname: l.Hostname, clientID: hex.EncodeToString(l.ClientID), expiry: "", state: l.State}
+		if l.PrefixLen != 0 {
+			r.ip += "/" + strconv.Itoa(l.PrefixLen)
+		}
+		if l.MAC != nil {
+			r.mac = hwaddr.AsColon(l.MAC)
+		}
+		if !l.Expiry.IsZero() {
+			r.expiry = l.Expiry.Format(time.RFC3339)
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+func TestParsers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		fixture string
+		parser  leases.Parser
+		want    []row
+	}{
+		{
+			fixture: "dhcpd.leases",
+			parser:  leases.ISCDhcpd,
+			want: []row{
+				{"192.168.1.10", "00:1b:21:0a:0b:0c", "laptop", "01001b210a0b0c", "2023-11-16T22:00:00Z", "active"},
+				{"192.168.1.23", "00:0c:29:aa:bb:cc", `vm "one"`, "01000c29aabbcc", "", "active"},
+				{"2001:db8::10", "00:02:c9:00:00:01", "", "000100012c9d18310002c9000001", "2023-11-16T22:00:00Z", "active"},
+				{"2001:db8:100::/56", "00:1b:21:00:00:01", "", "00030001001b21000001", "2023-11-17T12:00:00Z", "active"},
+			},
+		},
+		{
+			fixture: "dnsmasq.leases",
+			parser:  leases.Dnsmasq,
+			want: []row{
+				{"192.168.1.10", "00:1b:21:0a:0b:0c", "laptop", "01001b210a0b0c", "2023-11-16T12:00:00Z", "active"},
+				{"192.168.1.23", "00:0c:29:aa:bb:cc", "", "", "", "active"},
+				{"192.168.1.30", "00:02:c9:00:00:03", "printer", "ff0000000100012c9d18310002c9000003", "2023-11-16T12:00:00Z", "active"},
+				{"2001:db8::10", "00:02:c9:00:00:01", "", "000100012c9d18310002c9000001", "2023-11-16T12:00:00Z", "active"},
+				{"2001:db8::11", "", "phone", "0004123456789abcdef0123456789abcdef0", "2023-11-16T12:00:00Z", "active"},
+			},
+		},
+		{
+			fixture: "kea-leases4.csv",
+			parser:  leases.KeaMemfile,
+			want: []row{
+				{"192.168.1.10", "00:1b:21:0a:0b:0c", "laptop", "01001b210a0b0c", "2023-11-16T13:00:00Z", "active"},
+				{"192.168.1.40", "00:02:c9:00:00:02", "", "ff00000001000300010002c9000002", "2023-11-16T12:00:00Z", "declined"},
+			},
+		},
+		{
+			fixture: "kea-leases6.csv",
+			parser:  leases.KeaMemfile,
+			want: []row{
+				{"2001:db8::10", "00:02:c9:00:00:01", "server", "000100012c9d18310002c9000001", "2023-11-16T12:00:00Z", "active"},
+				{"2001:db8:100::/56", "00:1b:21:00:00:01", "", "00030001001b21000001", "2023-11-16T13:00:00Z", "active"},
+			},
+		},
+		{
+			fixture: "kea-lease-dump.csv",
+			parser:  leases.KeaCSV,
+			want: []row{
+				{"192.168.1.10", "00:1b:21:0a:0b:0c", "laptop", "01001b210a0b0c", "2023-11-16T12:00:00Z", "active"},
+				{"192.168.1.23", "00:0c:29:aa:bb:cc", "vm", "", "2023-11-16T12:00:00Z", "active"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.fixture, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := os.Open(filepath.Join("testdata", tt.fixture))
+			require.NoError(t, err)
+			defer f.Close()
+
+			got, err := tt.parser(f)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, toRows(got))
+		})
+	}
+}
+
+func TestKeaCSVKeepsHistory(t *testing.T) {
+	t.Parallel()
+
+	f, err := os.Open(filepath.Join("testdata", "kea-leases4.csv"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	got, err := leases.KeaCSV(f)
+	require.NoError(t, err)
+	require.Len(t, got, 5)
+	assert.Equal(t, "vm,one", got[1].Hostname)
+}
+
+func TestSyntaxErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"lease 192.168.1.10 {\n  ends never;\n",
+		"lease 192.168.1.10 { client-hostname \"laptop; }",
+		"}",
+		"lease 192.168.1.10",
+	} {
+		_, err := leases.ISCDhcpd(strings.NewReader(input))
+		require.ErrorIs(t, err, leases.ErrSyntax, input)
+	}
+
+	_, err := leases.KeaMemfile(strings.NewReader("ip,mac\n192.168.1.10,00:1b:21:0a:0b:0c\n"))
+	require.ErrorIs(t, err, leases.ErrSyntax)
+}
@@ -0,0 +1,117 @@
+/*
+Package mab spells MACs the way RADIUS attributes of MAC Authentication Bypass
+(MAB) carry them and renders entries of the users file of the FreeRADIUS files
+module (rlm_files).
+*/
+package mab
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// Options control how MACs are spelled in RADIUS attributes and entries.
+type Options struct {
+	// Format spells an EUI-48, e.g. [hwaddr.AsDash].
+	Format func([]byte) string
+	// Spell hex digits in upper case rather than lower.
+	Upper bool
+	// Reply attributes following every entry, verbatim.
+	Reply []string
+}
+
+// Spell formats a MAC the way it appears in RADIUS attributes.
+func (o Options) Spell(addr []byte) string {
+	s := o.Format(addr)
+	if o.Upper {
+		return strings.ToUpper(s)
+	}
+	return strings.ToLower(s)
+}
+
+// Parse parses a MAC of a MAB attribute, only EUI-48s are accepted.
+func Parse(s string) ([]byte, error) {
+	addr, err := hwaddr.ParseAddr(s)
+	if err != nil {
+		return nil, err //nolint: wrapcheck // already descriptive
+	}
+	if len(addr) != hwaddr.EUI48Len {
+		return nil, hwaddr.ParseError{
+			Input: s,
+			Msg:   fmt.Sprintf("MAB requires an EUI-48, got %d bytes", len(addr)),
+			Err:   hwaddr.ErrInputUnexpectedNumBytes,
+		}
+	}
+	return addr, nil
+}
+
+// AuthorizedMAC renders an entry of the MAC alone for a files module keyed on
+// Calling-Station-Id.
+func (o Options) AuthorizedMAC(addr []byte) string {
+	return o.entry(o.Spell(addr))
+}
+
+// User renders an entry of the MAC as both User-Name and password as sent by
+// switches doing MAB.
+func (o Options) User(addr []byte) string {
+	mac := o.Spell(addr)
+	return o.entry(fmt.Sprintf("%s Cleartext-Password := %q", mac, mac))
+}
+
+// Vendor renders a DEFAULT entry accepting MACs of an assignment in attribute.
+func (o Options) Vendor(attribute string, assignment string) (string, error) {
+	pattern, err := o.Pattern(assignment)
+	if err != nil {
+		return "", err
+	}
+	return o.entry(fmt.Sprintf("DEFAULT %s =~ %q, Auth-Type := Accept", attribute, pattern)), nil
+}
+
+// entry puts reply attributes on the lines following the first one.
+func (o Options) entry(first string) string {
+	if len(o.Reply) == 0 {
+		return first + "\n"
+	}
+	return first + "\n\t" + strings.Join(o.Reply, ",\n\t") + "\n"
+}
+
+/*
+Pattern converts a hex prefix of an assignment into a regular expression
+matching MACs of the assignment as spelled by o, e.g. "^70-B3-D5-7" for the
+MA-M 70B3D57. Dots are put into a bracket expression to not need escaping in
+the users file.
+*/
+func (o Options) Pattern(assignment string) (string, error) {
+	if len(assignment) > hwaddr.EUI48HexLen {
+		return "", fmt.Errorf("invalid assignment %q: longer than an EUI-48", assignment)
+	}
+	digits := assignment + strings.Repeat("0", hwaddr.EUI48HexLen-len(assignment))
+	addr, err := hex.DecodeString(digits)
+	if err != nil {
+		return "", fmt.Errorf("invalid assignment %q: %w", assignment, err)
+	}
+
+	var (
+		buf strings.Builder
+		n   int
+	)
+	buf.WriteString("^")
+	for _, c := range o.Spell(addr) {
+		if n == len(assignment) {
+			break
+		}
+		if c == '.' {
+			buf.WriteString("[.]")
+			continue
+		}
+		buf.WriteRune(c)
+		if c != ':' && c != '-' {
+			n++
+		}
+	}
+
+	return buf.String(), nil
+}
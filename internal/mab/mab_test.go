@@ -0,0 +1,115 @@
+package mab_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/mab"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestSpellAndPattern(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		opts mab.Options
+		mac  string
+		mal  string // 001B21
+		mam  string // 70B3D57
+		mas  string // 70B3D57ED
+	}{
+		{
+			name: "colon",
+			opts: mab.Options{Format: hwaddr.AsColon, Upper: false, Reply: nil},
+			mac:  "00:1b:21:0a:0b:0c",
+			mal:  "^00:1b:21",
+			mam:  "^70:b3:d5:7",
+			mas:  "^70:b3:d5:7e:d",
+		},
+		{
+			name: "dash upper",
+			opts: mab.Options{Format: hwaddr.AsDash, Upper: true, Reply: nil},
+			mac:  "00-1B-21-0A-0B-0C",
+			mal:  "^00-1B-21",
+			mam:  "^70-B3-D5-7",
+			mas:  "^70-B3-D5-7E-D",
+		},
+		{
+			name: "dot",
+			opts: mab.Options{Format: hwaddr.AsDot, Upper: false, Reply: nil},
+			mac:  "001b.210a.0b0c",
+			mal:  "^001b[.]21",
+			mam:  "^70b3[.]d57",
+			mas:  "^70b3[.]d57e[.]d",
+		},
+		{
+			name: "plain upper",
+			opts: mab.Options{Format: hwaddr.AsPlain, Upper: true, Reply: nil},
+			mac:  "001B210A0B0C",
+			mal:  "^001B21",
+			mam:  "^70B3D57",
+			mas:  "^70B3D57ED",
+		},
+	}
+
+	addr := []byte{0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.mac, tt.opts.Spell(addr))
+
+			for assignment, want := range map[string]string{"001B21": tt.mal, "70B3D57": tt.mam, "70B3D57ED": tt.mas} {
+				got, err := tt.opts.Pattern(assignment)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, assignment)
+			}
+		})
+	}
+}
+
+func TestPatternInvalid(t *testing.T) {
+	t.Parallel()
+
+	opts := mab.Options{Format: hwaddr.AsPlain, Upper: false, Reply: nil}
+
+	_, err := opts.Pattern("00XB21")
+	require.Error(t, err)
+
+	_, err = opts.Pattern("001B210A0B0C0D")
+	require.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"001b210a0b0c", "00-1B-21-0A-0B-0C", "001b.210a.0b0c"} {
+		addr, err := mab.Parse(input)
+		require.NoError(t, err)
+		assert.Equal(t, "00:1b:21:0a:0b:0c", hwaddr.AsColon(addr))
+	}
+
+	_, err := mab.Parse("00:1b:21:ff:fe:0a:0b:0c")
+	require.ErrorIs(t, err, hwaddr.ErrInputUnexpectedNumBytes)
+}
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	addr := []byte{0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c}
+	opts := mab.Options{Format: hwaddr.AsPlain, Upper: false, Reply: nil}
+
+	assert.Equal(t, "001b210a0b0c\n", opts.AuthorizedMAC(addr))
+	assert.Equal(t, "001b210a0b0c Cleartext-Password := \"001b210a0b0c\"\n", opts.User(addr))
+
+	opts.Reply = []string{"Tunnel-Type = VLAN", "Tunnel-Private-Group-Id = 20"}
+	assert.Equal(t, "001b210a0b0c\n\tTunnel-Type = VLAN,\n\tTunnel-Private-Group-Id = 20\n", opts.AuthorizedMAC(addr))
+
+	opts.Format, opts.Upper, opts.Reply = hwaddr.AsDash, true, nil
+	entry, err := opts.Vendor("Calling-Station-Id", "70B3D57ED")
+	require.NoError(t, err)
+	assert.Equal(t, "DEFAULT Calling-Station-Id =~ \"^70-B3-D5-7E-D\", Auth-Type := Accept\n", entry)
+}
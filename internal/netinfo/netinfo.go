@@ -0,0 +1,282 @@
+/*
+Package netinfo reads neighbor and interface tables of the local host from procfs
+and sysfs without netlink. Every function takes the root of the filesystem so
+that a fixture tree can stand in for the real one.
+*/
+package netinfo
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// ARP flags from include/uapi/linux/if_arp.h.
+const (
+	atfCom  = 0x02 // completed entry
+	atfPerm = 0x04 // permanent entry
+)
+
+// Neighbor is a complete entry of the IPv4 neighbor table.
+type Neighbor struct {
+	IP        netip.Addr
+	MAC       []byte
+	Interface string
+	Permanent bool
+}
+
+// Interface is a network interface with a hardware address.
+type Interface struct {
+	Name string
+	MAC  []byte
+	// Prefixes of IPv6 addresses assigned to the interface.
+	Prefixes []netip.Prefix
+}
+
+/*
+Neighbors reads the IPv4 neighbor table from /proc/net/arp. Incomplete entries
+are skipped. IPv6 neighbors are only available via netlink.
+*/
+func Neighbors(root string) ([]Neighbor, error) {
+	f, err := os.Open(filepath.Join(root, "proc", "net", "arp"))
+	if err != nil {
+		return nil, fmt.Errorf("reading neighbors: %w", err)
+	}
+	defer f.Close()
+
+	var neighbors []Neighbor
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if neighbor, ok := parseARPLine(scanner.Text()); ok {
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading neighbors: %w", err)
+	}
+
+	return neighbors, nil
+}
+
+// parseARPLine skips the header and incomplete entries.
+func parseARPLine(line string) (Neighbor, bool) {
+	const numFields = 6
+
+	fields := strings.Fields(line)
+	if len(fields) != numFields {
+		return Neighbor{}, false
+	}
+
+	ip, err := netip.ParseAddr(fields[0])
+	if err != nil {
+		return Neighbor{}, false
+	}
+	flags, err := strconv.ParseUint(strings.TrimPrefix(fields[2], "0x"), 16, 32)
+	if err != nil || flags&atfCom == 0 {
+		return Neighbor{}, false
+	}
+	mac, err := hwaddr.ParseAddr(fields[3])
+	if err != nil {
+		return Neighbor{}, false
+	}
+
+	return Neighbor{IP: ip, MAC: mac, Interface: fields[5], Permanent: flags&atfPerm != 0}, true
+}
+
+/*
+Interfaces lists interfaces with an EUI-48/EUI-64 hardware address from
+/sys/class/net and their IPv6 prefixes from /proc/net/if_inet6. Interfaces
+without an address, like loopback, are skipped. When sysfs is missing under the
+real root the standard library is asked instead.
+*/
+func Interfaces(root string) ([]Interface, error) {
+	sysNet := filepath.Join(root, "sys", "class", "net")
+
+	dirs, err := os.ReadDir(sysNet)
+	if errors.Is(err, fs.ErrNotExist) && filepath.Clean(root) == "/" {
+		return systemInterfaces()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading interfaces: %w", err)
+	}
+
+	prefixes, err := readInet6(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var ifaces []Interface
+
+	for _, dir := range dirs {
+		mac, ok := readAddress(filepath.Join(sysNet, dir.Name(), "address"))
+		if !ok {
+			continue
+		}
+
+		ifaces = append(ifaces, Interface{Name: dir.Name(), MAC: mac, Prefixes: prefixes[dir.Name()]})
+	}
+
+	return ifaces, nil
+}
+
+// readAddress returns false for unreadable, non-EUI and all-zero addresses.
+func readAddress(name string) ([]byte, bool) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, false
+	}
+	mac, err := hwaddr.ParseAddr(strings.TrimSpace(string(data)))
+	if err != nil || isZero(mac) {
+		return nil, false
+	}
+	return mac, true
+}
+
+// readInet6 returns IPv6 prefixes by interface name, the file may be missing
+// if IPv6 is disabled.
+func readInet6(root string) (map[string][]netip.Prefix, error) {
+	prefixes := make(map[string][]netip.Prefix)
+
+	f, err := os.Open(filepath.Join(root, "proc", "net", "if_inet6"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return prefixes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading IPv6 addresses: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, prefix, ok := parseInet6Line(scanner.Text())
+		if ok && !slices.Contains(prefixes[name], prefix) {
+			prefixes[name] = append(prefixes[name], prefix)
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading IPv6 addresses: %w", err)
+	}
+
+	return prefixes, nil
+}
+
+/*
+parseInet6Line parses a line of /proc/net/if_inet6: address, interface index,
+prefix length, scope, flags and interface name.
+*/
+func parseInet6Line(line string) (string, netip.Prefix, bool) {
+	const numFields = 6
+
+	fields := strings.Fields(line)
+	if len(fields) != numFields {
+		return "", netip.Prefix{}, false
+	}
+
+	raw, err := hex.DecodeString(fields[0])
+	if err != nil {
+		return "", netip.Prefix{}, false
+	}
+	addr, ok := netip.AddrFromSlice(raw)
+	if !ok {
+		return "", netip.Prefix{}, false
+	}
+	bits, err := strconv.ParseUint(fields[2], 16, 8)
+	if err != nil {
+		return "", netip.Prefix{}, false
+	}
+	prefix, err := addr.Prefix(int(bits))
+	if err != nil {
+		return "", netip.Prefix{}, false
+	}
+
+	return fields[5], prefix, true
+}
+
+func systemInterfaces() ([]Interface, error) {
+	netIfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, fmt.Errorf("reading interfaces: %w", err)
+	}
+
+	var ifaces []Interface
+
+	for _, netIface := range netIfaces {
+		mac := []byte(netIface.HardwareAddr)
+		if (len(mac) != hwaddr.EUI48Len && len(mac) != hwaddr.EUI64Len) || isZero(mac) {
+			continue
+		}
+
+		iface := Interface{Name: netIface.Name, MAC: mac, Prefixes: nil}
+
+		addrs, aerr := netIface.Addrs()
+		if aerr != nil {
+			return nil, fmt.Errorf("reading addresses of %s: %w", netIface.Name, aerr)
+		}
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.To4() != nil {
+				continue
+			}
+			ip, _ := netip.AddrFromSlice(ipNet.IP)
+			bits, _ := ipNet.Mask.Size()
+			if prefix, perr := ip.Prefix(bits); perr == nil && !slices.Contains(iface.Prefixes, prefix) {
+				iface.Prefixes = append(iface.Prefixes, prefix)
+			}
+		}
+
+		ifaces = append(ifaces, iface)
+	}
+
+	return ifaces, nil
+}
+
+/*
+SLAAC returns addresses that stateless address autoconfiguration (RFC 4862)
+derives from the hardware address of an interface for each of its /64
+prefixes, link-local included. Only EUI-48 interfaces are supported.
+*/
+func (i Interface) SLAAC() []netip.Addr {
+	const slaacBits = 64
+
+	eui48, err := hwaddr.EUI48FromBytes(i.MAC)
+	if err != nil {
+		return nil
+	}
+	eui64 := eui48.EUI64Modified()
+
+	var addrs []netip.Addr
+	for _, prefix := range i.Prefixes {
+		if prefix.Bits() != slaacBits {
+			continue
+		}
+		addr := hwaddr.AppendToPrefix(prefix, eui64)
+		if !slices.Contains(addrs, addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
+func isZero(addr []byte) bool {
+	for _, b := range addr {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
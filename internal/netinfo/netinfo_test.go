@@ -0,0 +1,69 @@
+package netinfo_test
+
+import (
+	"net/netip"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/netinfo"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+var root = filepath.Join("testdata", "root")
+
+func TestNeighbors(t *testing.T) {
+	t.Parallel()
+
+	neighbors, err := netinfo.Neighbors(root)
+	require.NoError(t, err)
+
+	type row struct {
+		ip, mac, iface string
+		permanent      bool
+	}
+	got := make([]row, 0, len(neighbors))
+	for _, n := range neighbors {
+		got = append(got, row{n.IP.String(), hwaddr.AsColon(n.MAC), n.Interface, n.Permanent})
+	}
+	assert.Equal(t, []row{
+		{"192.168.1.1", "00:1b:21:0a:0b:0c", "eth0", false},
+		{"192.168.1.23", "00:0c:29:aa:bb:cc", "eth0", false},
+		{"172.17.0.2", "02:42:ac:11:00:02", "docker0", true},
+	}, got)
+}
+
+func TestInterfaces(t *testing.T) {
+	t.Parallel()
+
+	ifaces, err := netinfo.Interfaces(root)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(ifaces))
+	slaac := make(map[string][]netip.Addr)
+	for _, iface := range ifaces {
+		names = append(names, iface.Name)
+		slaac[iface.Name] = iface.SLAAC()
+	}
+	assert.Equal(t, []string{"docker0", "eth0", "wlan0"}, names)
+
+	assert.Equal(t, []netip.Addr{
+		netip.MustParseAddr("fe80::21b:21ff:fe0a:b0d"),
+		netip.MustParseAddr("2001:db8:1::21b:21ff:fe0a:b0d"),
+	}, slaac["eth0"])
+	// The global prefix of wlan0 is not a /64.
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("fe80::a2b1:c2ff:fed3:e4f5")}, slaac["wlan0"])
+	assert.Empty(t, slaac["docker0"])
+}
+
+func TestMissingRoot(t *testing.T) {
+	t.Parallel()
+
+	_, err := netinfo.Neighbors(filepath.Join("testdata", "missing"))
+	require.Error(t, err)
+
+	_, err = netinfo.Interfaces(filepath.Join("testdata", "missing"))
+	require.Error(t, err)
+}
@@ -0,0 +1,316 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"slices"
+	"time"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+var ErrLinkType = errors.New("unsupported link type")
+
+// EtherTypes of the payloads addresses are taken from.
+const (
+	etherTypeIPv4  = 0x0800
+	etherTypeARP   = 0x0806
+	etherTypeVLAN  = 0x8100
+	etherTypeQinQ  = 0x88a8
+	etherTypeIPv6  = 0x86dd
+	macLen         = hwaddr.EUI48Len
+	etherTypeLen   = 2
+	vlanTagLen     = 4
+	ethernetHdrLen = 2*macLen + etherTypeLen
+)
+
+// Binding associates a hardware address with a protocol address it uses.
+type Binding struct {
+	MAC []byte
+	IP  netip.Addr
+}
+
+// Frame holds addresses of a decoded frame. Src is nil for 802.11 control
+// frames that carry only the receiver.
+type Frame struct {
+	Src      []byte
+	Dst      []byte
+	Bindings []Binding
+}
+
+// Decode extracts addresses of a frame of a link type. Frames too short to
+// carry both addresses are reported as not decoded.
+func Decode(linkType uint32, data []byte) (Frame, bool, error) {
+	switch linkType {
+	case LinkTypeEthernet:
+		frame, ok := decodeEthernet(data)
+		return frame, ok, nil
+	case LinkTypeIEEE80211:
+		frame, ok := decodeIEEE80211(data)
+		return frame, ok, nil
+	case LinkTypeRadiotap:
+		frame, ok := decodeRadiotap(data)
+		return frame, ok, nil
+	default:
+		return Frame{}, false, fmt.Errorf("%w %d", ErrLinkType, linkType)
+	}
+}
+
+func decodeEthernet(data []byte) (Frame, bool) {
+	if len(data) < ethernetHdrLen {
+		return Frame{}, false
+	}
+
+	frame := Frame{Src: data[macLen : 2*macLen], Dst: data[:macLen], Bindings: nil}
+
+	etherType := binary.BigEndian.Uint16(data[2*macLen:])
+	payload := data[ethernetHdrLen:]
+	for (etherType == etherTypeVLAN || etherType == etherTypeQinQ) && len(payload) >= vlanTagLen {
+		etherType = binary.BigEndian.Uint16(payload[etherTypeLen:])
+		payload = payload[vlanTagLen:]
+	}
+	frame.Bindings = decodePayload(etherType, frame.Src, payload)
+
+	return frame, true
+}
+
+// decodeRadiotap skips the radiotap header of a captured 802.11 frame.
+func decodeRadiotap(data []byte) (Frame, bool) {
+	const (
+		headerLenOffset = 2
+		minHeaderLen    = 8
+	)
+
+	if len(data) < minHeaderLen {
+		return Frame{}, false
+	}
+	headerLen := int(binary.LittleEndian.Uint16(data[headerLenOffset:]))
+	if headerLen < minHeaderLen || headerLen > len(data) {
+		return Frame{}, false
+	}
+
+	return decodeIEEE80211(data[headerLen:])
+}
+
+/*
+decodeIEEE80211 maps the addresses of an 802.11 frame to the source and the
+destination according to the To DS and From DS bits. Payloads of unprotected
+data frames are decoded when they are LLC/SNAP encapsulated.
+*/
+//nolint: mnd // offsets are defined by the layout
+func decodeIEEE80211(data []byte) (Frame, bool) {
+	const (
+		minHeaderLen  = 10
+		dataHeaderLen = 24
+		addr4Len      = 6
+		qosLen        = 2
+		typeControl   = 1
+		typeData      = 2
+		subtypeCTS    = 12
+		subtypeACK    = 13
+		subtypeQoS    = 0x08
+		flagToDS      = 0x01
+		flagFromDS    = 0x02
+		flagProtected = 0x40
+	)
+
+	if len(data) < minHeaderLen {
+		return Frame{}, false
+	}
+
+	frameType := data[0] >> 2 & 0x03
+	subtype := data[0] >> 4
+	flags := data[1]
+	addr1 := data[4:10]
+
+	if frameType == typeControl {
+		if subtype == subtypeCTS || subtype == subtypeACK || len(data) < 16 {
+			return Frame{Src: nil, Dst: addr1, Bindings: nil}, true
+		}
+		return Frame{Src: data[10:16], Dst: addr1, Bindings: nil}, true
+	}
+
+	if len(data) < dataHeaderLen {
+		return Frame{}, false
+	}
+	addr2, addr3 := data[10:16], data[16:22]
+
+	if frameType != typeData {
+		return Frame{Src: addr2, Dst: addr1, Bindings: nil}, true
+	}
+
+	headerLen := dataHeaderLen
+	frame := Frame{Src: addr2, Dst: addr1, Bindings: nil}
+
+	switch flags & (flagToDS | flagFromDS) {
+	case flagToDS:
+		frame.Dst = addr3
+	case flagFromDS:
+		frame.Src = addr3
+	case flagToDS | flagFromDS:
+		headerLen += addr4Len
+		if len(data) < headerLen {
+			return Frame{}, false
+		}
+		frame.Src, frame.Dst = data[24:30], addr3
+	}
+	if subtype&subtypeQoS != 0 {
+		headerLen += qosLen
+	}
+
+	if flags&flagProtected == 0 && len(data) >= headerLen {
+		if etherType, payload, ok := snap(data[headerLen:]); ok {
+			frame.Bindings = decodePayload(etherType, frame.Src, payload)
+		}
+	}
+
+	return frame, true
+}
+
+// snap strips the LLC/SNAP header carrying an EtherType.
+func snap(data []byte) (uint16, []byte, bool) {
+	header := []byte{0xaa, 0xaa, 0x03, 0x00, 0x00, 0x00}
+	if len(data) < len(header)+etherTypeLen || !slices.Equal(data[:len(header)], header) {
+		return 0, nil, false
+	}
+	return binary.BigEndian.Uint16(data[len(header):]), data[len(header)+etherTypeLen:], true
+}
+
+/*
+decodePayload binds the source address of IPv4 and IPv6 packets to the sender
+of the frame, so a router is bound to addresses of hosts it forwards packets of.
+ARP binds sender and target addresses of the packet itself.
+*/
+//nolint: mnd // offsets are defined by the layout
+func decodePayload(etherType uint16, src []byte, payload []byte) []Binding {
+	const (
+		ipv4HeaderLen = 20
+		ipv6HeaderLen = 40
+		arpLen        = 28
+	)
+
+	var bindings []Binding
+
+	switch etherType {
+	case etherTypeIPv4:
+		if len(payload) >= ipv4HeaderLen && payload[0]>>4 == 4 {
+			bindings = appendBinding(bindings, src, payload[12:16])
+		}
+	case etherTypeIPv6:
+		if len(payload) >= ipv6HeaderLen && payload[0]>>4 == 6 {
+			bindings = appendBinding(bindings, src, payload[8:24])
+		}
+	case etherTypeARP:
+		// Ethernet hardware and IPv4 protocol addresses only.
+		if len(payload) >= arpLen && payload[4] == macLen && payload[5] == 4 {
+			bindings = appendBinding(bindings, payload[8:14], payload[14:18])
+			bindings = appendBinding(bindings, payload[18:24], payload[24:28])
+		}
+	}
+
+	return bindings
+}
+
+// appendBinding skips addresses that do not identify a host: zero, broadcast
+// and multicast MACs, unspecified and multicast IPs.
+func appendBinding(bindings []Binding, mac []byte, ip []byte) []Binding {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok || !addr.IsValid() || addr.IsUnspecified() || addr.IsMulticast() {
+		return bindings
+	}
+	if hwaddr.IsMulticast(mac) || slices.Equal(mac, make([]byte, len(mac))) {
+		return bindings
+	}
+	return append(bindings, Binding{MAC: mac, IP: addr})
+}
+
+// Host is what a capture tells about a hardware address.
+type Host struct {
+	MAC      []byte
+	Sent     int
+	Received int
+	// Zero for pcapng Simple Packet Blocks that carry no timestamps.
+	First time.Time
+	Last  time.Time
+	IPs   []netip.Addr
+}
+
+/*
+Collect reads all packets of a capture and returns hosts sorted by MAC along
+with the number of packets skipped by unsupported link types, so interfaces of
+other link types in a pcapng capture do not prevent decoding the rest. Frames
+too short to decode are skipped as well.
+*/
+func Collect(r *Reader) ([]Host, map[uint32]int, error) {
+	hosts := make(map[string]*Host)
+	skipped := make(map[uint32]int)
+
+	host := func(mac []byte) *Host {
+		h, ok := hosts[string(mac)]
+		if !ok {
+			h = &Host{MAC: slices.Clone(mac), Sent: 0, Received: 0, First: time.Time{}, Last: time.Time{}, IPs: nil}
+			hosts[string(mac)] = h
+		}
+		return h
+	}
+
+	for {
+		packet, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		frame, ok, err := Decode(packet.LinkType, packet.Data)
+		if err != nil {
+			// The link type is unsupported.
+			skipped[packet.LinkType]++
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		if frame.Src != nil {
+			h := host(frame.Src)
+			h.Sent++
+			h.seen(packet.Timestamp)
+		}
+		h := host(frame.Dst)
+		h.Received++
+		h.seen(packet.Timestamp)
+
+		for _, binding := range frame.Bindings {
+			bound := host(binding.MAC)
+			if !slices.Contains(bound.IPs, binding.IP) {
+				bound.IPs = append(bound.IPs, binding.IP)
+			}
+		}
+	}
+
+	result := make([]Host, 0, len(hosts))
+	for _, h := range hosts {
+		slices.SortFunc(h.IPs, netip.Addr.Compare)
+		result = append(result, *h)
+	}
+	slices.SortFunc(result, func(a, b Host) int { return hwaddr.Compare(a.MAC, b.MAC) })
+
+	return result, skipped, nil
+}
+
+func (h *Host) seen(ts time.Time) {
+	if ts.IsZero() {
+		return
+	}
+	if h.First.IsZero() || ts.Before(h.First) {
+		h.First = ts
+	}
+	if ts.After(h.Last) {
+		h.Last = ts
+	}
+}
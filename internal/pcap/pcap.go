@@ -0,0 +1,424 @@
+/*
+Package pcap reads packet captures in the classic pcap and the pcapng formats
+and decodes link-layer addresses of Ethernet and IEEE 802.11 frames. Only what
+is needed to inventory hosts of a capture is decoded.
+*/
+package pcap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// Link types from https://www.tcpdump.org/linktypes.html.
+const (
+	LinkTypeEthernet  = 1
+	LinkTypeIEEE80211 = 105
+	LinkTypeRadiotap  = 127
+)
+
+var (
+	ErrFormat  = errors.New("not a pcap or pcapng capture")
+	ErrCorrupt = errors.New("corrupt capture")
+)
+
+// Bounds of allocations for corrupt length fields. A block holds a packet of
+// up to the snapshot length of libpcap along with its header and options.
+const (
+	maxPacketLen = 1 << 18
+	maxBlockLen  = maxPacketLen + 1<<16
+)
+
+// Packet is a captured frame.
+type Packet struct {
+	Timestamp time.Time
+	LinkType  uint32
+	Data      []byte
+}
+
+// Reader reads packets of a capture one by one.
+type Reader struct {
+	next func() (Packet, error)
+}
+
+// Next returns io.EOF after the last packet.
+func (r *Reader) Next() (Packet, error) {
+	return r.next()
+}
+
+// Magic numbers of capture files as read in the big-endian byte order.
+const (
+	magicMicroseconds        = 0xa1b2c3d4
+	magicMicrosecondsSwapped = 0xd4c3b2a1
+	magicNanoseconds         = 0xa1b23c4d
+	magicNanosecondsSwapped  = 0x4d3cb2a1
+	magicSectionHeader       = 0x0a0d0d0a
+)
+
+// NewReader detects the format of a capture by its magic number.
+func NewReader(r io.Reader) (*Reader, error) {
+	const magicLen = 4
+
+	br := bufio.NewReader(r)
+
+	magic, err := br.Peek(magicLen)
+	if err != nil {
+		return nil, ErrFormat
+	}
+
+	switch binary.BigEndian.Uint32(magic) {
+	case magicMicroseconds, magicMicrosecondsSwapped, magicNanoseconds, magicNanosecondsSwapped:
+		p, perr := newPcapReader(br)
+		if perr != nil {
+			return nil, perr
+		}
+		return &Reader{next: p.next}, nil
+	case magicSectionHeader:
+		n := &ngReader{r: br, order: nil, interfaces: nil}
+		return &Reader{next: n.next}, nil
+	default:
+		return nil, ErrFormat
+	}
+}
+
+// pcapReader reads the classic format of libpcap.
+type pcapReader struct {
+	r        io.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	linkType uint32
+}
+
+func newPcapReader(r io.Reader) (*pcapReader, error) {
+	const (
+		headerLen      = 24
+		linkTypeOffset = 20
+		// The upper bits of the link type field carry FCS information.
+		linkTypeMask = 0x0fffffff
+	)
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: reading file header: %w", ErrCorrupt, err)
+	}
+
+	p := &pcapReader{r: r, order: binary.BigEndian, nanos: false, linkType: 0}
+
+	switch binary.BigEndian.Uint32(header) {
+	case magicMicrosecondsSwapped:
+		p.order = binary.LittleEndian
+	case magicNanoseconds:
+		p.nanos = true
+	case magicNanosecondsSwapped:
+		p.order = binary.LittleEndian
+		p.nanos = true
+	}
+	p.linkType = p.order.Uint32(header[linkTypeOffset:]) & linkTypeMask
+
+	return p, nil
+}
+
+// nolint: mnd // offsets are defined by the layout
+func (p *pcapReader) next() (Packet, error) {
+	const recordHeaderLen = 16
+
+	header := make([]byte, recordHeaderLen)
+	if _, err := io.ReadFull(p.r, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Packet{}, io.EOF
+		}
+		return Packet{}, fmt.Errorf("%w: reading record header: %w", ErrCorrupt, err)
+	}
+
+	sec := p.order.Uint32(header[0:])
+	frac := p.order.Uint32(header[4:])
+	capLen := p.order.Uint32(header[8:])
+	if capLen > maxPacketLen {
+		return Packet{}, fmt.Errorf("%w: packet of %d bytes", ErrCorrupt, capLen)
+	}
+
+	data := make([]byte, capLen)
+	if _, err := io.ReadFull(p.r, data); err != nil {
+		return Packet{}, fmt.Errorf("%w: reading packet: %w", ErrCorrupt, err)
+	}
+
+	nsec := int64(frac) * int64(time.Microsecond)
+	if p.nanos {
+		nsec = int64(frac)
+	}
+
+	return Packet{Timestamp: time.Unix(int64(sec), nsec).UTC(), LinkType: p.linkType, Data: data}, nil
+}
+
+// ngInterface is what an Interface Description Block tells about packets.
+type ngInterface struct {
+	linkType uint32
+	snapLen  uint32
+	// units of a timestamp per second
+	resolution uint64
+}
+
+// ngReader reads the pcapng format. Every section has its own byte order and
+// interfaces.
+type ngReader struct {
+	r          io.Reader
+	order      binary.ByteOrder
+	interfaces []ngInterface
+}
+
+// Block types of pcapng.
+const (
+	blockInterfaceDescription = 0x00000001
+	blockPacket               = 0x00000002
+	blockSimplePacket         = 0x00000003
+	blockEnhancedPacket       = 0x00000006
+)
+
+func (n *ngReader) next() (Packet, error) {
+	for {
+		blockType, body, err := n.readBlock()
+		if err != nil {
+			return Packet{}, err
+		}
+
+		switch blockType {
+		case magicSectionHeader:
+			n.interfaces = nil
+		case blockInterfaceDescription:
+			err = n.addInterface(body)
+		case blockEnhancedPacket:
+			return n.enhancedPacket(body)
+		case blockSimplePacket:
+			return n.simplePacket(body)
+		case blockPacket:
+			return n.obsoletePacket(body)
+		}
+		if err != nil {
+			return Packet{}, err
+		}
+	}
+}
+
+/*
+readBlock returns the body of the next block without the type and both length
+fields.
+*/
+//nolint: mnd // offsets are defined by the layout
+func (n *ngReader) readBlock() (uint32, []byte, error) {
+	const (
+		blockHeaderLen = 8
+		trailerLen     = 4
+		byteOrderMagic = 0x1a2b3c4d
+	)
+
+	header := make([]byte, blockHeaderLen)
+	if _, err := io.ReadFull(n.r, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, nil, io.EOF
+		}
+		return 0, nil, fmt.Errorf("%w: reading block header: %w", ErrCorrupt, err)
+	}
+
+	// The byte order of a section follows the type of its header block.
+	if binary.BigEndian.Uint32(header) == magicSectionHeader {
+		bom := make([]byte, trailerLen)
+		if _, err := io.ReadFull(n.r, bom); err != nil {
+			return 0, nil, fmt.Errorf("%w: reading section header: %w", ErrCorrupt, err)
+		}
+		switch {
+		case binary.BigEndian.Uint32(bom) == byteOrderMagic:
+			n.order = binary.BigEndian
+		case binary.LittleEndian.Uint32(bom) == byteOrderMagic:
+			n.order = binary.LittleEndian
+		default:
+			return 0, nil, fmt.Errorf("%w: unknown byte order of a section", ErrCorrupt)
+		}
+		header = append(header, bom...)
+	}
+	if n.order == nil {
+		return 0, nil, fmt.Errorf("%w: block outside of a section", ErrCorrupt)
+	}
+
+	blockType := n.order.Uint32(header)
+	totalLen := n.order.Uint32(header[4:])
+	if totalLen < uint32(len(header))+trailerLen || totalLen%4 != 0 {
+		return 0, nil, fmt.Errorf("%w: block of %d bytes", ErrCorrupt, totalLen)
+	}
+	if totalLen > maxBlockLen {
+		switch blockType {
+		case blockInterfaceDescription, blockEnhancedPacket, blockSimplePacket, blockPacket:
+			return 0, nil, fmt.Errorf("%w: block of %d bytes", ErrCorrupt, totalLen)
+		}
+		// Other blocks, e.g. name resolution or decryption secrets, are not read.
+		if _, err := io.CopyN(io.Discard, n.r, int64(totalLen)-int64(len(header))); err != nil {
+			return 0, nil, fmt.Errorf("%w: reading block: %w", ErrCorrupt, err)
+		}
+		return blockType, nil, nil
+	}
+
+	rest := make([]byte, int(totalLen)-len(header))
+	if _, err := io.ReadFull(n.r, rest); err != nil {
+		return 0, nil, fmt.Errorf("%w: reading block: %w", ErrCorrupt, err)
+	}
+
+	if blockType == magicSectionHeader {
+		return blockType, nil, nil
+	}
+
+	return blockType, rest[:len(rest)-trailerLen], nil
+}
+
+// nolint: mnd // offsets are defined by the layout
+func (n *ngReader) addInterface(body []byte) error {
+	const (
+		fixedLen         = 8
+		optionEnd        = 0
+		optionResolution = 9
+		defaultExponent  = 6
+		base2Flag        = 0x80
+		binaryBase       = 2
+		decimal          = 10
+	)
+
+	if len(body) < fixedLen {
+		return fmt.Errorf("%w: short interface description block", ErrCorrupt)
+	}
+
+	iface := ngInterface{
+		linkType:   uint32(n.order.Uint16(body)),
+		snapLen:    n.order.Uint32(body[4:]),
+		resolution: pow(decimal, defaultExponent),
+	}
+
+	for options := body[fixedLen:]; len(options) >= 4; {
+		code := n.order.Uint16(options)
+		length := int(n.order.Uint16(options[2:]))
+		options = options[4:]
+		if code == optionEnd || length > len(options) {
+			break
+		}
+		if code == optionResolution && length == 1 {
+			if exp := options[0]; exp&base2Flag != 0 {
+				iface.resolution = pow(binaryBase, exp&^base2Flag)
+			} else {
+				iface.resolution = pow(decimal, exp)
+			}
+		}
+		options = options[min(len(options), (length+3)&^3):]
+	}
+
+	n.interfaces = append(n.interfaces, iface)
+
+	return nil
+}
+
+// nolint: mnd // offsets are defined by the layout
+func (n *ngReader) enhancedPacket(body []byte) (Packet, error) {
+	const fixedLen = 20
+
+	if len(body) < fixedLen {
+		return Packet{}, fmt.Errorf("%w: short enhanced packet block", ErrCorrupt)
+	}
+
+	iface, err := n.interfaceByID(n.order.Uint32(body))
+	if err != nil {
+		return Packet{}, err
+	}
+	ts := uint64(n.order.Uint32(body[4:]))<<32 | uint64(n.order.Uint32(body[8:]))
+	capLen := n.order.Uint32(body[12:])
+	if uint64(capLen) > uint64(len(body)-fixedLen) {
+		return Packet{}, fmt.Errorf("%w: packet of %d bytes", ErrCorrupt, capLen)
+	}
+
+	return Packet{
+		Timestamp: timestamp(ts, iface.resolution),
+		LinkType:  iface.linkType,
+		Data:      body[fixedLen : fixedLen+capLen],
+	}, nil
+}
+
+// simplePacket has neither a timestamp nor an interface, the first one is
+// implied.
+func (n *ngReader) simplePacket(body []byte) (Packet, error) {
+	const fixedLen = 4
+
+	if len(body) < fixedLen {
+		return Packet{}, fmt.Errorf("%w: short simple packet block", ErrCorrupt)
+	}
+
+	iface, err := n.interfaceByID(0)
+	if err != nil {
+		return Packet{}, err
+	}
+	capLen := min(n.order.Uint32(body), uint32(len(body)-fixedLen))
+	if iface.snapLen != 0 {
+		capLen = min(capLen, iface.snapLen)
+	}
+
+	return Packet{Timestamp: time.Time{}, LinkType: iface.linkType, Data: body[fixedLen : fixedLen+capLen]}, nil
+}
+
+// obsoletePacket is the Packet Block superseded by the Enhanced Packet Block.
+// nolint: mnd // offsets are defined by the layout
+func (n *ngReader) obsoletePacket(body []byte) (Packet, error) {
+	const fixedLen = 20
+
+	if len(body) < fixedLen {
+		return Packet{}, fmt.Errorf("%w: short packet block", ErrCorrupt)
+	}
+
+	iface, err := n.interfaceByID(uint32(n.order.Uint16(body)))
+	if err != nil {
+		return Packet{}, err
+	}
+	ts := uint64(n.order.Uint32(body[4:]))<<32 | uint64(n.order.Uint32(body[8:]))
+	capLen := n.order.Uint32(body[12:])
+	if uint64(capLen) > uint64(len(body)-fixedLen) {
+		return Packet{}, fmt.Errorf("%w: packet of %d bytes", ErrCorrupt, capLen)
+	}
+
+	return Packet{
+		Timestamp: timestamp(ts, iface.resolution),
+		LinkType:  iface.linkType,
+		Data:      body[fixedLen : fixedLen+capLen],
+	}, nil
+}
+
+func (n *ngReader) interfaceByID(id uint32) (ngInterface, error) {
+	if uint64(id) >= uint64(len(n.interfaces)) {
+		return ngInterface{}, fmt.Errorf("%w: packet of undescribed interface %d", ErrCorrupt, id)
+	}
+	return n.interfaces[id], nil
+}
+
+func timestamp(ts, resolution uint64) time.Time {
+	sec := ts / resolution
+	frac := ts % resolution
+	if sec > math.MaxInt64 {
+		return time.Time{}
+	}
+
+	nsec := frac * uint64(time.Second) / resolution
+	if resolution > uint64(time.Second) {
+		nsec = frac / (resolution / uint64(time.Second))
+	}
+
+	return time.Unix(int64(sec), int64(nsec)).UTC()
+}
+
+// pow saturates instead of overflowing for resolutions no one uses.
+func pow(base, exp uint8) uint64 {
+	result := uint64(1)
+	for range exp {
+		if result > math.MaxUint64/uint64(base) {
+			return math.MaxUint64
+		}
+		result *= uint64(base)
+	}
+	return result
+}
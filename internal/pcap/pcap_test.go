@@ -0,0 +1,252 @@
+package pcap_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/pcap"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+type host struct {
+	mac            string
+	sent, received int
+	first, last    time.Time
+	ips            []string
+}
+
+func collect(t *testing.T, fixture string) []host {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := pcap.NewReader(f)
+	require.NoError(t, err)
+
+	hosts, skipped, err := pcap.Collect(r)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+
+	got := make([]host, 0, len(hosts))
+	for _, h := range hosts {
+		ips := make([]string, 0, len(h.IPs))
+		for _, ip := range h.IPs {
+			ips = append(ips, ip.String())
+		}
+		got = append(got, host{hwaddr.AsColon(h.MAC), h.Sent, h.Received, h.First, h.Last, ips})
+	}
+	return got
+}
+
+func ts(sec, nsec int64) time.Time {
+	return time.Unix(sec, nsec).UTC()
+}
+
+func TestCollectPcap(t *testing.T) {
+	t.Parallel()
+
+	// ARP request and reply, IPv4 in 802.1Q, IPv6 to a multicast group and a
+	// truncated frame.
+	assert.Equal(t, []host{
+		{"00:02:c9:00:00:01", 1, 0, ts(1700000002, 0), ts(1700000002, 0), []string{"fe80::202:c9ff:fe00:1"}},
+		{"00:0c:29:aa:bb:cc", 1, 1, ts(1700000000, 200000), ts(1700000001, 500000000), []string{"192.168.1.23"}},
+		{"00:1b:21:0a:0b:0c", 2, 1, ts(1700000000, 100000), ts(1700000001, 500000000), []string{"192.168.1.1"}},
+		{"33:33:00:00:00:01", 0, 1, ts(1700000002, 0), ts(1700000002, 0), []string{}},
+		{"ff:ff:ff:ff:ff:ff", 0, 1, ts(1700000000, 100000), ts(1700000000, 100000), []string{}},
+	}, collect(t, "ethernet.pcap"))
+}
+
+func TestCollectPcapng(t *testing.T) {
+	t.Parallel()
+
+	/*
+		A little-endian section with an Ethernet interface of nanosecond
+		resolution and a radiotap one (a beacon, a QoS data frame to an AP and an
+		ACK), a simple packet block without a timestamp, then a big-endian section.
+	*/
+	assert.Equal(t, []host{
+		{"00:02:c9:00:00:01", 2, 1, ts(1700000100, 250), ts(1700000200, 0), []string{"10.1.1.1", "2001:db8::1"}},
+		{"00:0c:29:aa:bb:cc", 1, 2, ts(1700000100, 250), ts(1700000200, 0), []string{"192.168.1.23"}},
+		{"00:1b:21:0a:0b:0c", 1, 2, ts(1700000102, 0), ts(1700000102, 10000), []string{"10.0.0.5"}},
+		{"28:6f:b9:00:00:01", 1, 0, ts(1700000101, 5000), ts(1700000101, 5000), []string{}},
+		{"ff:ff:ff:ff:ff:ff", 0, 1, ts(1700000101, 5000), ts(1700000101, 5000), []string{}},
+	}, collect(t, "mixed.pcapng"))
+}
+
+func TestNewReaderRejectsOtherFormats(t *testing.T) {
+	t.Parallel()
+
+	_, err := pcap.NewReader(bytes.NewReader([]byte("00:1b:21:0a:0b:0c\n")))
+	require.ErrorIs(t, err, pcap.ErrFormat)
+
+	_, err = pcap.NewReader(bytes.NewReader(nil))
+	require.ErrorIs(t, err, pcap.ErrFormat)
+}
+
+func TestCorruptCapture(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "ethernet.pcap"))
+	require.NoError(t, err)
+
+	r, err := pcap.NewReader(bytes.NewReader(data[:len(data)-2]))
+	require.NoError(t, err)
+
+	_, _, err = pcap.Collect(r)
+	require.ErrorIs(t, err, pcap.ErrCorrupt)
+}
+
+// ngBlock lays out a little-endian pcapng block.
+func ngBlock(blockType uint32, body []byte) []byte {
+	body = append(body, make([]byte, -len(body)&3)...)
+	totalLen := uint32(len(body) + 12)
+
+	block := binary.LittleEndian.AppendUint32(nil, blockType)
+	block = binary.LittleEndian.AppendUint32(block, totalLen)
+	block = append(block, body...)
+	return binary.LittleEndian.AppendUint32(block, totalLen)
+}
+
+// ngCapture is a section with an interface of every link type followed by
+// blocks.
+func ngCapture(linkTypes []uint16, blocks ...[]byte) []byte {
+	capture := ngBlock(0x0a0d0d0a, []byte{
+		0x4d, 0x3c, 0x2b, 0x1a, 0x01, 0x00, 0x00, 0x00,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	})
+	for _, linkType := range linkTypes {
+		idb := binary.LittleEndian.AppendUint16(nil, linkType)
+		idb = append(idb, 0x00, 0x00, 0x00, 0x00, 0x04, 0x00)
+		capture = append(capture, ngBlock(0x00000001, idb)...)
+	}
+	for _, block := range blocks {
+		capture = append(capture, block...)
+	}
+	return capture
+}
+
+// ngPacket is an Enhanced Packet Block of an interface with an option.
+func ngPacket(iface uint32, data []byte) []byte {
+	body := binary.LittleEndian.AppendUint32(nil, iface)
+	body = append(body, make([]byte, 8)...)
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(data)))
+	body = binary.LittleEndian.AppendUint32(body, uint32(len(data)))
+	body = append(body, data...)
+	body = append(body, make([]byte, -len(body)&3)...)
+	// opt_comment and opt_endofopt
+	body = append(body, 0x01, 0x00, 0x04, 0x00, 'n', 'o', 't', 'e', 0x00, 0x00, 0x00, 0x00)
+	return ngBlock(0x00000006, body)
+}
+
+func collectCapture(t *testing.T, capture []byte) ([]pcap.Host, map[uint32]int) {
+	t.Helper()
+
+	r, err := pcap.NewReader(bytes.NewReader(capture))
+	require.NoError(t, err)
+
+	hosts, skipped, err := pcap.Collect(r)
+	require.NoError(t, err)
+
+	return hosts, skipped
+}
+
+func TestCollectSkipsUnsupportedLinkTypes(t *testing.T) {
+	t.Parallel()
+
+	const linkTypeLinuxSLL = 113
+
+	frame := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c, 0x88, 0xb5,
+	}
+	hosts, skipped := collectCapture(t, ngCapture(
+		[]uint16{pcap.LinkTypeEthernet, linkTypeLinuxSLL},
+		ngPacket(1, make([]byte, 64)),
+		ngPacket(0, frame),
+		ngPacket(1, make([]byte, 64)),
+	))
+
+	require.Len(t, hosts, 2)
+	assert.Equal(t, "00:1b:21:0a:0b:0c", hwaddr.AsColon(hosts[0].MAC))
+	assert.Equal(t, map[uint32]int{linkTypeLinuxSLL: 2}, skipped)
+}
+
+func TestCollectLargeBlocks(t *testing.T) {
+	t.Parallel()
+
+	// A packet of the default snapshot length of libpcap.
+	frame := make([]byte, 262144)
+	copy(frame, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c, 0x88, 0xb5})
+	// A name resolution block beyond any packet is skipped.
+	nrb := ngBlock(0x00000004, make([]byte, 1<<20))
+
+	hosts, _ := collectCapture(t, ngCapture([]uint16{pcap.LinkTypeEthernet}, nrb, ngPacket(0, frame)))
+
+	require.Len(t, hosts, 2)
+	assert.Equal(t, "00:1b:21:0a:0b:0c", hwaddr.AsColon(hosts[0].MAC))
+
+	r, err := pcap.NewReader(bytes.NewReader(ngCapture(
+		[]uint16{pcap.LinkTypeEthernet}, ngPacket(0, make([]byte, 1<<20)),
+	)))
+	require.NoError(t, err)
+	_, _, err = pcap.Collect(r)
+	require.ErrorIs(t, err, pcap.ErrCorrupt)
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := pcap.Decode(113, make([]byte, 64))
+	require.ErrorIs(t, err, pcap.ErrLinkType)
+
+	// A frame between two stations of the same BSS, From DS and To DS unset.
+	frame, ok, err := pcap.Decode(pcap.LinkTypeIEEE80211, []byte{
+		0x08, 0x00, 0x00, 0x00,
+		0x00, 0x0c, 0x29, 0xaa, 0xbb, 0xcc,
+		0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c,
+		0x28, 0x6f, 0xb9, 0x00, 0x00, 0x01,
+		0x00, 0x00,
+	})
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "00:1b:21:0a:0b:0c", hwaddr.AsColon(frame.Src))
+	assert.Equal(t, "00:0c:29:aa:bb:cc", hwaddr.AsColon(frame.Dst))
+	assert.Empty(t, frame.Bindings)
+
+	_, ok, err = pcap.Decode(pcap.LinkTypeEthernet, []byte{0x00, 0x1b})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestBindingsSkipUnspecified(t *testing.T) {
+	t.Parallel()
+
+	// An ARP probe has the unspecified sender address.
+	probe := []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c, 0x08, 0x06,
+		0x00, 0x01, 0x08, 0x00, 0x06, 0x04, 0x00, 0x01,
+		0x00, 0x1b, 0x21, 0x0a, 0x0b, 0x0c, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xc0, 0xa8, 0x01, 0x17,
+	}
+	frame, ok, err := pcap.Decode(pcap.LinkTypeEthernet, probe)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Empty(t, frame.Bindings)
+
+	// A gratuitous ARP binds the address once per side.
+	copy(probe[28:32], []byte{0xc0, 0xa8, 0x01, 0x17})
+	copy(probe[32:38], probe[22:28])
+	frame, _, err = pcap.Decode(pcap.LinkTypeEthernet, probe)
+	require.NoError(t, err)
+	want := pcap.Binding{MAC: probe[22:28], IP: netip.MustParseAddr("192.168.1.23")}
+	assert.Equal(t, []pcap.Binding{want, want}, frame.Bindings)
+}
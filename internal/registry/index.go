@@ -0,0 +1,375 @@
+package registry
+
+import (
+	"cmp"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+/*
+Index is an inverted index of records by words of names and addresses of
+organizations. Words are folded, see [Fold]. Searches look words of a query up
+in the vocabulary of the index and verify only the records holding them.
+*/
+type Index struct {
+	Records []Record
+	// Names maps words of OrgName to ascending positions in Records.
+	Names map[string][]int
+	// Addresses maps words of OrgAddress to ascending positions in Records.
+	Addresses map[string][]int
+}
+
+// Letters without a decomposition into a base letter and a combining mark.
+var foldReplacer = strings.NewReplacer( //nolint: gochecknoglobals // lookup table
+	"ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "đ", "d", "ð", "d", "ł", "l", "þ", "th", "ı", "i",
+)
+
+/*
+Fold converts s to lower case and strips diacritics, e.g. "Société Générale"
+becomes "societe generale".
+*/
+func Fold(s string) string {
+	folded, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), strings.ToLower(s),
+	)
+	if err != nil {
+		folded = strings.ToLower(s)
+	}
+	return foldReplacer.Replace(folded)
+}
+
+// words splits folded s into runs of letters and digits.
+func words(s string) []string {
+	return strings.FieldsFunc(Fold(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// foldSpace folds s and collapses runs of whitespace into a single space.
+func foldSpace(s string) string {
+	return strings.Join(strings.Fields(Fold(s)), " ")
+}
+
+// NewIndex indexes records sorted by assignment and registry.
+func NewIndex(records []Record) *Index {
+	sorted := slices.Clone(records)
+	slices.SortStableFunc(sorted, func(a, b Record) int {
+		if c := strings.Compare(a.Assignment, b.Assignment); c != 0 {
+			return c
+		}
+		return strings.Compare(string(a.Registry), string(b.Registry))
+	})
+
+	index := &Index{
+		Records:   sorted,
+		Names:     make(map[string][]int),
+		Addresses: make(map[string][]int),
+	}
+	for i, record := range sorted {
+		addPostings(index.Names, record.OrgName, i)
+		addPostings(index.Addresses, record.OrgAddress, i)
+	}
+
+	return index
+}
+
+func addPostings(postings map[string][]int, s string, i int) {
+	for _, word := range words(s) {
+		if ids := postings[word]; len(ids) == 0 || ids[len(ids)-1] != i {
+			postings[word] = append(ids, i)
+		}
+	}
+}
+
+/*
+Substring returns records whose OrgName, or OrgAddress when address is set,
+contains query ignoring case, diacritics and runs of whitespace.
+*/
+func (x *Index) Substring(query string, address bool) []Record {
+	folded := foldSpace(query)
+	return x.collect(x.candidates(query, address, strings.Contains), func(record Record) bool {
+		return strings.Contains(foldSpace(record.OrgName), folded) ||
+			address && strings.Contains(foldSpace(record.OrgAddress), folded)
+	})
+}
+
+/*
+Regexp returns records whose OrgName, or OrgAddress when address is set,
+matches re as is or folded. Only records holding words of the literals every
+match of re contains are tried, every record when re has no such literals, e.g.
+"^[0-9]".
+*/
+func (x *Index) Regexp(re *regexp.Regexp, address bool) []Record {
+	ids, ok := x.regexpCandidates(re, address)
+	if !ok {
+		ids = make([]int, len(x.Records))
+		for i := range ids {
+			ids[i] = i
+		}
+	}
+	match := func(s string) bool {
+		return re.MatchString(s) || re.MatchString(Fold(s))
+	}
+	return x.collect(ids, func(record Record) bool {
+		return match(record.OrgName) || address && match(record.OrgAddress)
+	})
+}
+
+/*
+regexpCandidates returns ascending positions of records that may match re. A
+literal of a match is a substring of the matched text, so words of the literal
+are contained in words of the record. Branches of a top-level alternation are
+narrowed separately. ok is false when there are no literals to narrow by.
+*/
+func (x *Index) regexpCandidates(re *regexp.Regexp, address bool) ([]int, bool) {
+	parsed, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil, false
+	}
+
+	branches := []*syntax.Regexp{parsed.Simplify()}
+	if branches[0].Op == syntax.OpAlternate {
+		branches = branches[0].Sub
+	}
+
+	var result []int
+	for _, branch := range branches {
+		literals := strings.Join(requiredLiterals(branch), " ")
+		if len(words(literals)) == 0 {
+			return nil, false
+		}
+		result = append(result, x.candidates(literals, address, strings.Contains)...)
+	}
+	slices.Sort(result)
+
+	return slices.Compact(result), true
+}
+
+// requiredLiterals returns literals every match of re contains.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return []string{string(re.Rune)}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return nil
+		}
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpConcat:
+		var literals []string
+		for _, sub := range re.Sub {
+			literals = append(literals, requiredLiterals(sub)...)
+		}
+		return literals
+	default:
+		return nil
+	}
+}
+
+/*
+Fuzzy returns records having, for every word of query, a word starting with it
+or a word within a few edits of it, ignoring case and diacritics. Up to one
+edit is allowed for words of four to seven letters and two for longer ones.
+Records are ordered by the total number of edits, then by assignment.
+*/
+func (x *Index) Fuzzy(query string, address bool) []Record {
+	queryWords := words(query)
+	if len(queryWords) == 0 {
+		return nil
+	}
+
+	distances := make(map[int]int)
+	for n, queryWord := range queryWords {
+		best := make(map[int]int)
+		for _, postings := range x.postings(address) {
+			for word, ids := range postings {
+				distance, ok := fuzzyMatch(queryWord, word)
+				if !ok {
+					continue
+				}
+				for _, id := range ids {
+					if d, found := best[id]; !found || distance < d {
+						best[id] = distance
+					}
+				}
+			}
+		}
+
+		for id, distance := range best {
+			if total, found := distances[id]; n == 0 || found {
+				distances[id] = total + distance
+			}
+		}
+		for id := range distances {
+			if _, found := best[id]; !found {
+				delete(distances, id)
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(distances))
+	for id := range distances {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b int) int {
+		if c := cmp.Compare(distances[a], distances[b]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	records := make([]Record, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, x.Records[id])
+	}
+
+	return records
+}
+
+/*
+candidates returns ascending positions of records having, for every word of
+query, a word that satisfies match.
+*/
+func (x *Index) candidates(query string, address bool, match func(word, queryWord string) bool) []int {
+	queryWords := words(query)
+	if len(queryWords) == 0 {
+		return nil
+	}
+
+	var result []int
+	for n, queryWord := range queryWords {
+		found := make(map[int]struct{})
+		for _, postings := range x.postings(address) {
+			for word, ids := range postings {
+				if !match(word, queryWord) {
+					continue
+				}
+				for _, id := range ids {
+					found[id] = struct{}{}
+				}
+			}
+		}
+
+		if n == 0 {
+			for id := range found {
+				result = append(result, id)
+			}
+			continue
+		}
+		result = slices.DeleteFunc(result, func(id int) bool {
+			_, ok := found[id]
+			return !ok
+		})
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+func (x *Index) postings(address bool) []map[string][]int {
+	if address {
+		return []map[string][]int{x.Names, x.Addresses}
+	}
+	return []map[string][]int{x.Names}
+}
+
+func (x *Index) collect(ids []int, keep func(Record) bool) []Record {
+	var records []Record
+	for _, id := range ids {
+		if keep(x.Records[id]) {
+			records = append(records, x.Records[id])
+		}
+	}
+	return records
+}
+
+// fuzzyMatch reports whether word starts with or is close to queryWord and
+// the number of edits apart.
+func fuzzyMatch(queryWord, word string) (int, bool) {
+	if strings.HasPrefix(word, queryWord) {
+		return 0, true
+	}
+
+	q, w := []rune(queryWord), []rune(word)
+	limit := maxEdits(len(q))
+	if limit == 0 || abs(len(q)-len(w)) > limit {
+		return 0, false
+	}
+
+	distance := levenshtein(q, w)
+	return distance, distance <= limit
+}
+
+/*
+maxEdits returns the number of edits allowed for a word of n letters.
+*/
+//nolint: mnd // thresholds of typos
+func maxEdits(n int) int {
+	switch {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (x *Index) EncodeGOB(w io.Writer) error {
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(x); err != nil {
+		return fmt.Errorf("error encoding index: %w", err)
+	}
+
+	return nil
+}
+
+func (x *Index) DecodeGOB(r io.Reader) error {
+	decoder := gob.NewDecoder(r)
+	if err := decoder.Decode(x); err != nil {
+		return fmt.Errorf("error decoding index: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package registry_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/registry"
+)
+
+func testIndex() *registry.Index {
+	return registry.NewIndex([]registry.Record{
+		{Assignment: "F09FC2", Registry: registry.NameMAL, OrgName: "Ubiquiti Inc", OrgAddress: "New York NY US"},
+		{Assignment: "245A4C", Registry: registry.NameMAL, OrgName: "Ubiquiti Inc", OrgAddress: "New York NY US"},
+		{Assignment: "001B21", Registry: registry.NameMAL, OrgName: "Intel Corporate", OrgAddress: "Kulim Kedah MY"},
+		{Assignment: "0024D6", Registry: registry.NameMAL, OrgName: "Intel  Corporate", OrgAddress: "Kulim Kedah MY"},
+		{Assignment: "70B3D57", Registry: registry.NameMAM, OrgName: "Société Générale", OrgAddress: "Paris FR"},
+		{Assignment: "8C1F64ABC", Registry: registry.NameMAS, OrgName: "Zürich Ltd", OrgAddress: "Bern CH"},
+	})
+}
+
+func assignments(records []registry.Record) []string {
+	result := make([]string, 0, len(records))
+	for _, record := range records {
+		result = append(result, record.Assignment)
+	}
+	return result
+}
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "societe generale", registry.Fold("Société Générale"))
+	assert.Equal(t, "strasse ostergaard", registry.Fold("Straße Østergaard"))
+}
+
+func TestIndexSubstring(t *testing.T) {
+	cases := []struct {
+		query   string
+		address bool
+		want    []string
+	}{
+		{query: "ubiq", want: []string{"245A4C", "F09FC2"}},
+		{query: "UBIQUITI INC", want: []string{"245A4C", "F09FC2"}},
+		{query: "intel corporate", want: []string{"001B21", "0024D6"}},
+		{query: "tel corp", want: []string{"001B21", "0024D6"}},
+		{query: "societe gen", want: []string{"70B3D57"}},
+		{query: "zurich", want: []string{"8C1F64ABC"}},
+		{query: "corporate intel", want: []string{}},
+		{query: "kedah", want: []string{}},
+		{query: "kedah", address: true, want: []string{"001B21", "0024D6"}},
+		{query: "---", want: []string{}},
+	}
+
+	index := testIndex()
+	for i, tt := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, assignments(index.Substring(tt.query, tt.address)))
+		})
+	}
+}
+
+func TestIndexRegexp(t *testing.T) {
+	cases := []struct {
+		expr    string
+		address bool
+		want    []string
+	}{
+		{expr: `(?i)^soci[eé]t[eé]`, want: []string{"70B3D57"}},
+		{expr: `^zurich`, want: []string{"8C1F64ABC"}},
+		{expr: `US$`, address: true, want: []string{"245A4C", "F09FC2"}},
+		{expr: `(?i)tel\s+corp`, want: []string{"001B21", "0024D6"}},
+		{expr: `(?i)(zürich|ubiquiti) (ltd|inc)`, want: []string{"245A4C", "8C1F64ABC", "F09FC2"}},
+		{expr: `(?i)^(?:intel|soci)`, want: []string{"001B21", "0024D6", "70B3D57"}},
+		{expr: `Kulim`, want: []string{}},
+		{expr: `Kulim`, address: true, want: []string{"001B21", "0024D6"}},
+		// Nothing to narrow by, every record is tried.
+		{expr: `^[a-z]+ [a-z]+$`, want: []string{"001B21", "245A4C", "70B3D57", "8C1F64ABC", "F09FC2"}},
+		{expr: `(?i)inc|ltd`, want: []string{"245A4C", "8C1F64ABC", "F09FC2"}},
+	}
+
+	index := testIndex()
+	for i, tt := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			got := index.Regexp(regexp.MustCompile(tt.expr), tt.address)
+			assert.Equal(t, tt.want, assignments(got))
+		})
+	}
+}
+
+func TestIndexFuzzy(t *testing.T) {
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{query: "ubiquity", want: []string{"245A4C", "F09FC2"}},
+		{query: "ubiqiuti", want: []string{"245A4C", "F09FC2"}},
+		// The example of the help of oui search.
+		{query: "ubiqiti inc", want: []string{"245A4C", "F09FC2"}},
+		{query: "intl corporate", want: []string{"001B21", "0024D6"}},
+		{query: "societe", want: []string{"70B3D57"}},
+		{query: "zurick", want: []string{"8C1F64ABC"}},
+		{query: "ubiquity intel", want: []string{}},
+		{query: "xyz", want: []string{}},
+	}
+
+	index := testIndex()
+	for i, tt := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, assignments(index.Fuzzy(tt.query, false)))
+		})
+	}
+}
+
+func TestIndexFuzzyOrder(t *testing.T) {
+	index := registry.NewIndex([]registry.Record{
+		{Assignment: "000001", Registry: registry.NameMAL, OrgName: "Acme Netwerks", OrgAddress: ""},
+		{Assignment: "000002", Registry: registry.NameMAL, OrgName: "Acme Networks", OrgAddress: ""},
+	})
+
+	assert.Equal(t, []string{"000002", "000001"}, assignments(index.Fuzzy("acme networks", false)))
+}
+
+func TestIndexGOB(t *testing.T) {
+	index := testIndex()
+
+	var buf bytes.Buffer
+	require.NoError(t, index.EncodeGOB(&buf))
+
+	var decoded registry.Index
+	require.NoError(t, decoded.DecodeGOB(&buf))
+	assert.Equal(t, index, &decoded)
+}
@@ -4,10 +4,14 @@ package registry
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
 // ENUM(MA-L, MA-M, MA-S, CID).
@@ -20,6 +24,129 @@ type Record struct {
 	OrgAddress string `json:"org_address"`
 }
 
+// Lengths of assignments in bits.
+const (
+	bitsMAL = 24
+	bitsMAM = 28
+	bitsMAS = 36
+	bitsCID = 24
+
+	eui48Bits = 8 * hwaddr.EUI48Len
+)
+
+// BitLength returns the length of assignments of the registry in bits.
+func (x Name) BitLength() int {
+	switch x {
+	case NameMAL:
+		return bitsMAL
+	case NameMAM:
+		return bitsMAM
+	case NameMAS:
+		return bitsMAS
+	case NameCID:
+		return bitsCID
+	}
+	return 0
+}
+
+// BitLength returns the length of the assignment in bits defined by the
+// registry.
+func (r Record) BitLength() int {
+	return r.Registry.BitLength()
+}
+
+/*
+Block returns the addresses of the assignment as a [hwaddr.Block] of the length
+of the registry. Assignments that are not hex yield an empty prefix.
+*/
+func (r Record) Block() hwaddr.Block {
+	bits := r.BitLength()
+
+	var prefix uint64
+	if n, err := strconv.ParseUint(r.Assignment, 16, 64); err == nil {
+		prefix = n << (64 - len(r.Assignment)*hwaddr.NibbleBits) & (^uint64(0) << (64 - bits))
+	}
+
+	return hwaddr.Block{Prefix: hwaddr.EUI64FromUint64(prefix), Bits: bits}
+}
+
+// First returns the first EUI-48 of the assignment.
+func (r Record) First() hwaddr.EUI48 {
+	var first hwaddr.EUI48
+	block := r.Block()
+	copy(first[:], block.Prefix[:])
+	return first
+}
+
+// Last returns the last EUI-48 of the assignment.
+func (r Record) Last() hwaddr.EUI48 {
+	var last hwaddr.EUI48
+	block := r.Block()
+	end := hwaddr.EUI64FromUint64(block.Prefix.Uint64() | ^uint64(0)>>block.Bits)
+	copy(last[:], end[:])
+	return last
+}
+
+// Size returns the number of EUI-48s in the assignment.
+func (r Record) Size() uint64 {
+	return 1 << (eui48Bits - r.BitLength())
+}
+
+// MarshalJSON adds the bit length, the first and the last EUI-48 and the size
+// of the assignment to the fields of the record.
+func (r Record) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(r.toJSON())
+	if err != nil {
+		return nil, fmt.Errorf("error encoding record: %w", err)
+	}
+
+	return data, nil
+}
+
+type plainRecord Record
+
+type recordJSON struct {
+	plainRecord
+	BitLength int    `json:"bit_length"`
+	First     string `json:"first"`
+	Last      string `json:"last"`
+	Size      uint64 `json:"size"`
+}
+
+func (r Record) toJSON() recordJSON {
+	return recordJSON{
+		plainRecord: plainRecord(r),
+		BitLength:   r.BitLength(),
+		First:       r.First().String(),
+		Last:        r.Last().String(),
+		Size:        r.Size(),
+	}
+}
+
+// Match is a record of one of the prefixes matching a lookup, see
+// [Trie.AllMatches].
+type Match struct {
+	Record
+	// MostSpecific marks records of the longest matching prefix.
+	MostSpecific bool
+}
+
+// MarshalJSON encodes the match as its record with a most_specific field.
+func (m Match) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		recordJSON
+		MostSpecific bool `json:"most_specific"`
+	}{
+		recordJSON:   m.toJSON(),
+		MostSpecific: m.MostSpecific,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding match: %w", err)
+	}
+
+	return data, nil
+}
+
 func ParseRecordsFromCSV(r io.Reader) ([]Record, error) {
 	var records []Record
 
@@ -1,6 +1,7 @@
 package registry_test
 
 import (
+	"encoding/json"
 	"strconv"
 	"strings"
 	"testing"
@@ -50,3 +51,81 @@ MA-S,8C1F6480A,ASDF Corporation,"Address: 20F.-1, No. 8, County TW 30244 "
 		})
 	}
 }
+
+func TestRecordRange(t *testing.T) {
+	cases := []struct {
+		record registry.Record
+		bits   int
+		first  string
+		last   string
+		size   uint64
+	}{
+		{
+			record: registry.Record{Assignment: "001B21", Registry: registry.NameMAL, OrgName: "", OrgAddress: ""},
+			bits:   24, first: "00:1b:21:00:00:00", last: "00:1b:21:ff:ff:ff", size: 1 << 24,
+		},
+		{
+			record: registry.Record{Assignment: "70B3D57", Registry: registry.NameMAM, OrgName: "", OrgAddress: ""},
+			bits:   28, first: "70:b3:d5:70:00:00", last: "70:b3:d5:7f:ff:ff", size: 1 << 20,
+		},
+		{
+			record: registry.Record{Assignment: "8C1F64ABA", Registry: registry.NameMAS, OrgName: "", OrgAddress: ""},
+			bits:   36, first: "8c:1f:64:ab:a0:00", last: "8c:1f:64:ab:af:ff", size: 1 << 12,
+		},
+		{
+			record: registry.Record{Assignment: "0A1B2C", Registry: registry.NameCID, OrgName: "", OrgAddress: ""},
+			bits:   24, first: "0a:1b:2c:00:00:00", last: "0a:1b:2c:ff:ff:ff", size: 1 << 24,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.record.Assignment, func(t *testing.T) {
+			assert.Equal(t, tt.bits, tt.record.BitLength())
+			assert.Equal(t, tt.first, tt.record.First().String())
+			assert.Equal(t, tt.last, tt.record.Last().String())
+			assert.Equal(t, tt.size, tt.record.Size())
+		})
+	}
+}
+
+func TestRecordMarshalJSON(t *testing.T) {
+	record := registry.Record{
+		Assignment: "70B3D57ED", Registry: registry.NameMAS, OrgName: "The Things Industries B.V.", OrgAddress: "Amsterdam NL",
+	}
+
+	data, err := json.Marshal(record)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"assignment": "70B3D57ED",
+		"registry": "MA-S",
+		"org_name": "The Things Industries B.V.",
+		"org_address": "Amsterdam NL",
+		"bit_length": 36,
+		"first": "70:b3:d5:7e:d0:00",
+		"last": "70:b3:d5:7e:df:ff",
+		"size": 4096
+	}`, string(data))
+}
+
+func TestMatchMarshalJSON(t *testing.T) {
+	match := registry.Match{
+		Record: registry.Record{
+			Assignment: "70B3D5", Registry: registry.NameMAL, OrgName: "IEEE Registration Authority", OrgAddress: "",
+		},
+		MostSpecific: false,
+	}
+
+	data, err := json.Marshal(match)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"assignment": "70B3D5",
+		"registry": "MA-L",
+		"org_name": "IEEE Registration Authority",
+		"org_address": "",
+		"bit_length": 24,
+		"first": "70:b3:d5:00:00:00",
+		"last": "70:b3:d5:ff:ff:ff",
+		"size": 16777216,
+		"most_specific": false
+	}`, string(data))
+}
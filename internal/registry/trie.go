@@ -4,6 +4,11 @@ import (
 	"encoding/gob"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
 type TrieNode struct {
@@ -78,6 +83,117 @@ func (t *Trie) LongestPrefixMatch(prefix string) []Record {
 	return []Record{}
 }
 
+/*
+AllMatches returns records of every node on the path of prefix, from the
+shortest assignment to the longest, e.g. the MA-L of the IEEE Registration
+Authority followed by an MA-S carved out of it. Records of the longest one,
+the ones [Trie.LongestPrefixMatch] returns, are marked as most specific.
+*/
+func (t *Trie) AllMatches(prefix string) []Match {
+	var (
+		matches []Match
+		longest int
+	)
+
+	node := t.Root
+	for _, b := range prefix {
+		nextNode, found := node.Children[b]
+		if !found {
+			break
+		}
+		node = nextNode
+		if len(node.Records) == 0 {
+			continue
+		}
+
+		longest = len(matches)
+		for _, record := range node.Records {
+			matches = append(matches, Match{Record: record, MostSpecific: false})
+		}
+	}
+
+	for i := longest; i < len(matches); i++ {
+		matches[i].MostSpecific = true
+	}
+
+	return matches
+}
+
+/*
+Subtree returns records of the node of prefix and of all the nodes below it
+sorted by assignment, e.g. an MA-L and the MA-S blocks carved out of it. Records
+of the same assignment keep the order of insertion.
+*/
+func (t *Trie) Subtree(prefix string) []Record {
+	node := t.Root
+	for _, b := range prefix {
+		nextNode, found := node.Children[b]
+		if !found {
+			return nil
+		}
+		node = nextNode
+	}
+
+	return collect(nil, node)
+}
+
+/*
+Overlapping returns records of assignments sharing any address with block
+sorted by assignment: the ones containing the block and the ones inside it. The
+length of the block is not limited to whole hex digits, e.g. 001B20/22.
+*/
+func (t *Trie) Overlapping(block hwaddr.Block) []Record {
+	digits := strings.ToUpper(hwaddr.AsPlain(block.Prefix[:]))
+	whole, rest := block.Bits/hwaddr.NibbleBits, block.Bits%hwaddr.NibbleBits
+
+	var records []Record
+	node := t.Root
+	for _, b := range digits[:whole] {
+		records = append(records, node.Records...)
+		nextNode, found := node.Children[b]
+		if !found {
+			return records
+		}
+		node = nextNode
+	}
+
+	if rest == 0 {
+		return collect(records, node)
+	}
+
+	// The first digit past the block decides which children are inside it.
+	records = append(records, node.Records...)
+	for _, key := range slices.Sorted(maps.Keys(node.Children)) {
+		child, err := hwaddr.ParseBlock(digits[:whole] + string(key))
+		if err == nil && block.Overlaps(child) {
+			records = collect(records, node.Children[key])
+		}
+	}
+
+	return records
+}
+
+// collect appends records of node and of all the nodes below it in the order
+// of assignments.
+func collect(records []Record, node *TrieNode) []Record {
+	stack := []*TrieNode{node}
+
+	for len(stack) > 0 {
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		records = append(records, node.Records...)
+
+		// Children are pushed in reverse so the smallest is visited first.
+		keys := slices.Sorted(maps.Keys(node.Children))
+		for i := len(keys) - 1; i >= 0; i-- {
+			stack = append(stack, node.Children[keys[i]])
+		}
+	}
+
+	return records
+}
+
 func (t *Trie) Traverse() []Record {
 	var allRecords []Record
 	if t.Root == nil {
@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ttl256/euivator/internal/registry"
+	"github.com/ttl256/euivator/pkg/hwaddr"
 )
 
 func TestTrie(t *testing.T) {
@@ -50,3 +52,105 @@ func TestTrie(t *testing.T) {
 		})
 	}
 }
+
+func TestTrieSubtree(t *testing.T) {
+	trie := registry.NewTrie()
+	trie.InsertMany([]registry.Record{
+		{Assignment: "8C1F64FFF", Registry: registry.NameMAS, OrgName: "C", OrgAddress: "C"},
+		{Assignment: "8C1F64", Registry: registry.NameMAL, OrgName: "IEEE", OrgAddress: "IEEE"},
+		{Assignment: "8C1F64000", Registry: registry.NameMAS, OrgName: "A", OrgAddress: "A"},
+		{Assignment: "8C1F6400A", Registry: registry.NameMAS, OrgName: "B", OrgAddress: "B"},
+		{Assignment: "8C1F65", Registry: registry.NameMAL, OrgName: "D", OrgAddress: "D"},
+		{Assignment: "001B21", Registry: registry.NameMAL, OrgName: "E", OrgAddress: "E"},
+	})
+
+	cases := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "8C1F64", want: []string{"8C1F64", "8C1F64000", "8C1F6400A", "8C1F64FFF"}},
+		{prefix: "8C1F6", want: []string{"8C1F64", "8C1F64000", "8C1F6400A", "8C1F64FFF", "8C1F65"}},
+		{prefix: "8C1F64F", want: []string{"8C1F64FFF"}},
+		{prefix: "", want: []string{"001B21", "8C1F64", "8C1F64000", "8C1F6400A", "8C1F64FFF", "8C1F65"}},
+		{prefix: "ABC", want: []string{}},
+	}
+
+	for i, tt := range cases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			assert.Equal(t, tt.want, assignments(trie.Subtree(tt.prefix)))
+		})
+	}
+}
+
+func TestTrieOverlapping(t *testing.T) {
+	trie := registry.NewTrie()
+	trie.InsertMany([]registry.Record{
+		{Assignment: "001B21", Registry: registry.NameMAL, OrgName: "A", OrgAddress: "A"},
+		{Assignment: "001B20", Registry: registry.NameMAL, OrgName: "B", OrgAddress: "B"},
+		{Assignment: "001B2F", Registry: registry.NameMAL, OrgName: "C", OrgAddress: "C"},
+		{Assignment: "001B30", Registry: registry.NameMAL, OrgName: "D", OrgAddress: "D"},
+		{Assignment: "001B217", Registry: registry.NameMAM, OrgName: "E", OrgAddress: "E"},
+		{Assignment: "001B217ED", Registry: registry.NameMAS, OrgName: "F", OrgAddress: "F"},
+	})
+
+	cases := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "00:1b:21:00:00:00/20", want: []string{"001B20", "001B21", "001B217", "001B217ED", "001B2F"}},
+		{prefix: "001B20/22", want: []string{"001B20", "001B21", "001B217", "001B217ED"}},
+		{prefix: "001B20/23", want: []string{"001B20", "001B21", "001B217", "001B217ED"}},
+		{prefix: "001B21/24", want: []string{"001B21", "001B217", "001B217ED"}},
+		{prefix: "00:1b:21:7e:00:00/26", want: []string{"001B21", "001B217", "001B217ED"}},
+		{prefix: "00:1b:21:7e:d1:23/40", want: []string{"001B21", "001B217", "001B217ED"}},
+		{prefix: "00:1b:21:80:00:00/28", want: []string{"001B21"}},
+		{prefix: "001B31/24", want: []string{}},
+		{prefix: "00:1b:21:ff:fe:0a:0b:0c/24", want: []string{"001B21", "001B217", "001B217ED"}},
+		{prefix: "00:1b:21:7e:d0:ff:fe:01/64", want: []string{"001B21", "001B217", "001B217ED"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.prefix, func(t *testing.T) {
+			block, err := hwaddr.ParsePrefix(tt.prefix)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, assignments(trie.Overlapping(block)))
+		})
+	}
+}
+
+func TestTrieAllMatches(t *testing.T) {
+	trie := registry.NewTrie()
+	trie.InsertMany([]registry.Record{
+		{Assignment: "70B3D5", Registry: registry.NameMAL, OrgName: "IEEE Registration Authority", OrgAddress: ""},
+		{Assignment: "70B3D57ED", Registry: registry.NameMAS, OrgName: "The Things Industries B.V.", OrgAddress: ""},
+		{Assignment: "70B3D57EE", Registry: registry.NameMAS, OrgName: "Other", OrgAddress: ""},
+		{Assignment: "001B21", Registry: registry.NameMAL, OrgName: "Intel Corporate", OrgAddress: ""},
+		{Assignment: "001B21", Registry: registry.NameMAL, OrgName: "Intel Duplicate", OrgAddress: ""},
+	})
+
+	type level struct {
+		assignment   string
+		mostSpecific bool
+	}
+
+	cases := []struct {
+		prefix string
+		want   []level
+	}{
+		{prefix: "70B3D57ED123", want: []level{{"70B3D5", false}, {"70B3D57ED", true}}},
+		{prefix: "70B3D5123456", want: []level{{"70B3D5", true}}},
+		{prefix: "001B21", want: []level{{"001B21", true}, {"001B21", true}}},
+		{prefix: "70B3", want: []level{}},
+		{prefix: "ABCDEF", want: []level{}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.prefix, func(t *testing.T) {
+			got := []level{}
+			for _, match := range trie.AllMatches(tt.prefix) {
+				got = append(got, level{match.Assignment, match.MostSpecific})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
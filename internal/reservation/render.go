@@ -0,0 +1,186 @@
+package reservation
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+// Family selects the addresses of targets configured per address family.
+type Family int
+
+const (
+	IPv4 Family = 4
+	IPv6 Family = 6
+)
+
+// Renderer writes the configuration of a target. Targets that take both
+// families in a single file ignore the family.
+type Renderer func(w io.Writer, hosts []Host, family Family) error
+
+func (f Family) addr(host Host) netip.Addr {
+	if f == IPv6 {
+		return host.IPv6
+	}
+	return host.IPv4
+}
+
+/*
+ISCDhcpd renders host declarations of ISC dhcpd. dhcpd and dhcpd -6 are
+configured separately so hosts without an address of the family are left out:
+
+	host laptop {
+	  hardware ethernet 00:1b:21:0a:0b:0c;
+	  fixed-address 192.168.1.10;
+	}
+*/
+func ISCDhcpd(w io.Writer, hosts []Host, family Family) error {
+	statement := "fixed-address"
+	if family == IPv6 {
+		statement = "fixed-address6"
+	}
+
+	var buf strings.Builder
+	for _, host := range hosts {
+		ip := family.addr(host)
+		if !ip.IsValid() {
+			continue
+		}
+		fmt.Fprintf(&buf, "host %s {\n  hardware ethernet %s;\n  %s %s;\n}\n",
+			host.Name, hwaddr.AsColon(host.MAC), statement, ip)
+	}
+
+	return write(w, buf.String())
+}
+
+type keaReservation struct {
+	HWAddress   string   `json:"hw-address"`
+	IPAddress   string   `json:"ip-address,omitempty"`
+	IPAddresses []string `json:"ip-addresses,omitempty"`
+	Hostname    string   `json:"hostname"`
+}
+
+/*
+KeaJSON renders a list of Kea host reservations to be included as the value of
+"reservations" of Dhcp4 or Dhcp6, hosts without an address of the family are
+left out:
+
+	[
+	  {
+	    "hw-address": "00:1b:21:0a:0b:0c",
+	    "ip-address": "192.168.1.10",
+	    "hostname": "laptop"
+	  }
+	]
+*/
+func KeaJSON(w io.Writer, hosts []Host, family Family) error {
+	reservations := make([]keaReservation, 0, len(hosts))
+	for _, host := range hosts {
+		ip := family.addr(host)
+		if !ip.IsValid() {
+			continue
+		}
+
+		reservation := keaReservation{
+			HWAddress:   hwaddr.AsColon(host.MAC),
+			IPAddress:   "",
+			IPAddresses: nil,
+			Hostname:    host.Name,
+		}
+		if family == IPv6 {
+			reservation.IPAddresses = []string{ip.String()}
+		} else {
+			reservation.IPAddress = ip.String()
+		}
+		reservations = append(reservations, reservation)
+	}
+
+	data, err := json.MarshalIndent(reservations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding reservations: %w", err)
+	}
+	data = append(data, '\n')
+
+	return write(w, string(data))
+}
+
+/*
+Dnsmasq renders dhcp-host options of dnsmasq with addresses of both families:
+
+	dhcp-host=00:1b:21:0a:0b:0c,192.168.1.10,[2001:db8::10],laptop
+*/
+func Dnsmasq(w io.Writer, hosts []Host, _ Family) error {
+	var buf strings.Builder
+	for _, host := range hosts {
+		fields := []string{hwaddr.AsColon(host.MAC)}
+		if host.IPv4.IsValid() {
+			fields = append(fields, host.IPv4.String())
+		}
+		if host.IPv6.IsValid() {
+			fields = append(fields, "["+host.IPv6.String()+"]")
+		}
+		fields = append(fields, host.Name)
+		fmt.Fprintf(&buf, "dhcp-host=%s\n", strings.Join(fields, ","))
+	}
+
+	return write(w, buf.String())
+}
+
+/*
+Ethers renders /etc/ethers mapping MACs to hostnames:
+
+	00:1b:21:0a:0b:0c laptop
+*/
+func Ethers(w io.Writer, hosts []Host, _ Family) error {
+	var buf strings.Builder
+	for _, host := range hosts {
+		fmt.Fprintf(&buf, "%s %s\n", hwaddr.AsColon(host.MAC), host.Name)
+	}
+
+	return write(w, buf.String())
+}
+
+/*
+OpenWrtUCI renders host sections of /etc/config/dhcp. The IPv6 address becomes
+the hostid, the interface identifier odhcpd appends to the prefix in hex:
+
+	config host
+		option name 'laptop'
+		option mac '00:1b:21:0a:0b:0c'
+		option ip '192.168.1.10'
+		option hostid '10'
+*/
+func OpenWrtUCI(w io.Writer, hosts []Host, _ Family) error {
+	const interfaceIDOffset = 8
+
+	var buf strings.Builder
+	for i, host := range hosts {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "config host\n\toption name '%s'\n\toption mac '%s'\n", host.Name, hwaddr.AsColon(host.MAC))
+		if host.IPv4.IsValid() {
+			fmt.Fprintf(&buf, "\toption ip '%s'\n", host.IPv4)
+		}
+		if host.IPv6.IsValid() {
+			ip := host.IPv6.As16()
+			hostid := binary.BigEndian.Uint64(ip[interfaceIDOffset:])
+			fmt.Fprintf(&buf, "\toption hostid '%s'\n", strconv.FormatUint(hostid, 16))
+		}
+	}
+
+	return write(w, buf.String())
+}
+
+func write(w io.Writer, s string) error {
+	if _, err := io.WriteString(w, s); err != nil {
+		return fmt.Errorf("writing configuration: %w", err)
+	}
+	return nil
+}
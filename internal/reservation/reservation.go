@@ -0,0 +1,224 @@
+/*
+Package reservation validates an inventory of hosts and renders it into static
+DHCP reservations and address mappings of ISC dhcpd, Kea, dnsmasq, OpenWrt and
+/etc/ethers.
+*/
+package reservation
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"strings"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+var (
+	ErrSyntax          = errors.New("syntax error")
+	ErrInvalidHostname = errors.New("invalid hostname")
+	ErrInvalidAddress  = errors.New("invalid address")
+	ErrDuplicate       = errors.New("duplicate")
+	ErrMulticast       = errors.New("multicast MAC")
+	ErrLocal           = errors.New("locally administered MAC")
+)
+
+// LineError is a problem of a row of an inventory.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e LineError) Unwrap() error {
+	return e.Err
+}
+
+// Host is a row of an inventory, addresses absent from the row are invalid
+// [netip.Addr] values.
+type Host struct {
+	Name string
+	MAC  []byte
+	IPv4 netip.Addr
+	IPv6 netip.Addr
+}
+
+// Options relax checks of [Read].
+type Options struct {
+	// Accept MACs with the I/G bit set.
+	AllowMulticast bool
+	// Accept MACs with the U/L bit set.
+	AllowLocal bool
+}
+
+// Columns of an inventory without a header.
+var defaultColumns = map[string]int{ //nolint: gochecknoglobals // lookup table
+	"hostname": 0,
+	"mac":      1,
+	"ipv4":     2,
+	"ipv6":     3,
+}
+
+/*
+Read parses an inventory in CSV:
+
+	hostname,mac,ipv4,ipv6
+	laptop,00:1b:21:0a:0b:0c,192.168.1.10,2001:db8::10
+	printer,00-0c-29-aa-bb-cc,192.168.1.20,
+
+The header is optional and columns may go in any order when it is present,
+ipv4 and ipv6 may be omitted. Lines starting with # are comments. Every row is
+validated and all the problems found are returned at once as joined
+[LineError]s: malformed hostnames and addresses, MACs other than EUI-48,
+multicast and locally administered MACs unless allowed and MACs and IPs that
+are used more than once.
+*/
+func Read(r io.Reader, opts Options) ([]Host, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var (
+		hosts   []Host
+		columns = defaultColumns
+		seen    = make(map[string]int)
+		errs    []error
+	)
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading inventory: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first && isHeader(record) {
+			columns = make(map[string]int, len(record))
+			for i, name := range record {
+				columns[strings.ToLower(strings.TrimSpace(name))] = i
+			}
+			if _, ok := columns["mac"]; !ok {
+				return nil, fmt.Errorf("%w: no mac column in %q", ErrSyntax, strings.Join(record, ","))
+			}
+			continue
+		}
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		host, err := parseHost(get("hostname"), get("mac"), get("ipv4"), get("ipv6"), opts)
+		if err != nil {
+			errs = append(errs, LineError{Line: line, Err: err})
+			continue
+		}
+
+		keys := []string{"MAC " + hwaddr.AsColon(host.MAC)}
+		for _, ip := range []netip.Addr{host.IPv4, host.IPv6} {
+			if ip.IsValid() {
+				keys = append(keys, "IP "+ip.String())
+			}
+		}
+		for _, key := range keys {
+			if prev, ok := seen[key]; ok {
+				errs = append(errs, LineError{
+					Line: line,
+					Err:  fmt.Errorf("%w %s, first used on line %d", ErrDuplicate, key, prev),
+				})
+				continue
+			}
+			seen[key] = line
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return hosts, nil
+}
+
+// isHeader reports whether a record names columns rather than holds a host.
+func isHeader(record []string) bool {
+	for _, field := range record {
+		if strings.EqualFold(strings.TrimSpace(field), "mac") {
+			return true
+		}
+	}
+	return false
+}
+
+func parseHost(name, mac, ipv4, ipv6 string, opts Options) (Host, error) {
+	host := Host{Name: name, MAC: nil, IPv4: netip.Addr{}, IPv6: netip.Addr{}}
+
+	if !validHostname(name) {
+		return Host{}, fmt.Errorf("%w %q", ErrInvalidHostname, name)
+	}
+
+	addr, err := hwaddr.ParseAddr(mac)
+	if err != nil {
+		return Host{}, err //nolint: wrapcheck // already descriptive
+	}
+	if len(addr) != hwaddr.EUI48Len {
+		return Host{}, fmt.Errorf("%w %q: reservations require an EUI-48", ErrInvalidAddress, mac)
+	}
+	if hwaddr.IsMulticast(addr) && !opts.AllowMulticast {
+		return Host{}, fmt.Errorf("%w %s", ErrMulticast, hwaddr.AsColon(addr))
+	}
+	if hwaddr.IsLocal(addr) && !opts.AllowLocal {
+		return Host{}, fmt.Errorf("%w %s", ErrLocal, hwaddr.AsColon(addr))
+	}
+	host.MAC = addr
+
+	if ipv4 != "" {
+		host.IPv4, err = netip.ParseAddr(ipv4)
+		if err != nil || !host.IPv4.Is4() {
+			return Host{}, fmt.Errorf("%w %q: expected IPv4", ErrInvalidAddress, ipv4)
+		}
+	}
+	if ipv6 != "" {
+		host.IPv6, err = netip.ParseAddr(ipv6)
+		if err != nil || !host.IPv6.Is6() || host.IPv6.Is4In6() || host.IPv6.Zone() != "" {
+			return Host{}, fmt.Errorf("%w %q: expected IPv6", ErrInvalidAddress, ipv6)
+		}
+	}
+
+	return host, nil
+}
+
+// validHostname accepts dot separated labels of letters, digits and hyphens
+// not starting or ending with a hyphen.
+func validHostname(name string) bool {
+	const maxLabelLen = 63
+
+	if name == "" {
+		return false
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > maxLabelLen || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,213 @@
+package reservation_test
+
+import (
+	"bytes"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/internal/reservation"
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func readFixture(t *testing.T, name string, opts reservation.Options) ([]reservation.Host, error) {
+	t.Helper()
+
+	f, err := os.Open(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	defer f.Close()
+
+	return reservation.Read(f, opts)
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	hosts, err := readFixture(t, "inventory.csv", reservation.Options{AllowMulticast: false, AllowLocal: false})
+	require.NoError(t, err)
+	require.Len(t, hosts, 3)
+
+	assert.Equal(t, "laptop", hosts[0].Name)
+	assert.Equal(t, "00:1b:21:0a:0b:0c", hwaddr.AsColon(hosts[0].MAC))
+	assert.Equal(t, netip.MustParseAddr("192.168.1.10"), hosts[0].IPv4)
+	assert.Equal(t, netip.MustParseAddr("2001:db8::10"), hosts[0].IPv6)
+
+	assert.Equal(t, "00:0c:29:aa:bb:cc", hwaddr.AsColon(hosts[1].MAC))
+	assert.False(t, hosts[1].IPv6.IsValid())
+
+	assert.False(t, hosts[2].IPv4.IsValid())
+	assert.Equal(t, "00:02:c9:00:00:01", hwaddr.AsColon(hosts[2].MAC))
+}
+
+func TestReadWithoutHeader(t *testing.T) {
+	t.Parallel()
+
+	hosts, err := reservation.Read(
+		strings.NewReader("laptop,00:1b:21:0a:0b:0c,192.168.1.10\n"),
+		reservation.Options{AllowMulticast: false, AllowLocal: false},
+	)
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	assert.Equal(t, netip.MustParseAddr("192.168.1.10"), hosts[0].IPv4)
+}
+
+func TestReadInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := readFixture(t, "invalid.csv", reservation.Options{AllowMulticast: false, AllowLocal: false})
+	require.Error(t, err)
+
+	for _, want := range []error{
+		reservation.ErrDuplicate,
+		reservation.ErrLocal,
+		reservation.ErrMulticast,
+		reservation.ErrInvalidHostname,
+		reservation.ErrInvalidAddress,
+	} {
+		require.ErrorIs(t, err, want)
+	}
+
+	var lineErr reservation.LineError
+	require.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, 3, lineErr.Line)
+
+	lines := strings.Split(err.Error(), "\n")
+	assert.Equal(t, []string{
+		"line 3: duplicate MAC 00:1b:21:0a:0b:0c, first used on line 2",
+		"line 4: locally administered MAC 02:42:ac:11:00:02",
+		"line 5: multicast MAC 01:00:5e:00:00:fb",
+		"line 6: duplicate IP 192.168.1.10, first used on line 2",
+		`line 7: invalid hostname "bad_name"`,
+		`line 8: invalid address "2001:db8::30": expected IPv4`,
+	}, lines)
+}
+
+func TestReadAllow(t *testing.T) {
+	t.Parallel()
+
+	hosts, err := reservation.Read(
+		strings.NewReader("vm,02:42:ac:11:00:02\ngroup,01:00:5e:00:00:fb\n"),
+		reservation.Options{AllowMulticast: true, AllowLocal: true},
+	)
+	require.NoError(t, err)
+	assert.Len(t, hosts, 2)
+}
+
+func TestRenderers(t *testing.T) {
+	t.Parallel()
+
+	hosts, err := readFixture(t, "inventory.csv", reservation.Options{AllowMulticast: false, AllowLocal: false})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		renderer reservation.Renderer
+		family   reservation.Family
+		want     string
+	}{
+		{
+			name:     "isc-dhcpd",
+			renderer: reservation.ISCDhcpd,
+			family:   reservation.IPv4,
+			want: `host laptop {
+  hardware ethernet 00:1b:21:0a:0b:0c;
+  fixed-address 192.168.1.10;
+}
+host printer {
+  hardware ethernet 00:0c:29:aa:bb:cc;
+  fixed-address 192.168.1.20;
+}
+`,
+		},
+		{
+			name:     "isc-dhcpd6",
+			renderer: reservation.ISCDhcpd,
+			family:   reservation.IPv6,
+			want: `host laptop {
+  hardware ethernet 00:1b:21:0a:0b:0c;
+  fixed-address6 2001:db8::10;
+}
+host nas {
+  hardware ethernet 00:02:c9:00:00:01;
+  fixed-address6 2001:db8::1:20;
+}
+`,
+		},
+		{
+			name:     "kea-json6",
+			renderer: reservation.KeaJSON,
+			family:   reservation.IPv6,
+			want: `[
+  {
+    "hw-address": "00:1b:21:0a:0b:0c",
+    "ip-addresses": [
+      "2001:db8::10"
+    ],
+    "hostname": "laptop"
+  },
+  {
+    "hw-address": "00:02:c9:00:00:01",
+    "ip-addresses": [
+      "2001:db8::1:20"
+    ],
+    "hostname": "nas"
+  }
+]
+`,
+		},
+		{
+			name:     "dnsmasq",
+			renderer: reservation.Dnsmasq,
+			family:   reservation.IPv4,
+			want: `dhcp-host=00:1b:21:0a:0b:0c,192.168.1.10,[2001:db8::10],laptop
+dhcp-host=00:0c:29:aa:bb:cc,192.168.1.20,printer
+dhcp-host=00:02:c9:00:00:01,[2001:db8::1:20],nas
+`,
+		},
+		{
+			name:     "ethers",
+			renderer: reservation.Ethers,
+			family:   reservation.IPv4,
+			want: `00:1b:21:0a:0b:0c laptop
+00:0c:29:aa:bb:cc printer
+00:02:c9:00:00:01 nas
+`,
+		},
+		{
+			name:     "openwrt-uci",
+			renderer: reservation.OpenWrtUCI,
+			family:   reservation.IPv4,
+			want: `config host
+	option name 'laptop'
+	option mac '00:1b:21:0a:0b:0c'
+	option ip '192.168.1.10'
+	option hostid '10'
+
+config host
+	option name 'printer'
+	option mac '00:0c:29:aa:bb:cc'
+	option ip '192.168.1.20'
+
+config host
+	option name 'nas'
+	option mac '00:02:c9:00:00:01'
+	option hostid '10020'
+`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, tt.renderer(&buf, hosts, tt.family))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
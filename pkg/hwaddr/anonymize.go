@@ -0,0 +1,52 @@
+package hwaddr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// PseudonymizeOptions controls which parts of an address survive
+// [Pseudonymize].
+type PseudonymizeOptions struct {
+	// PreserveOUI keeps the first three bytes so that vendor analytics still work.
+	PreserveOUI bool
+	// PreserveBits keeps the I/G and U/L bits of the first byte.
+	PreserveBits bool
+}
+
+/*
+Pseudonymize maps an address to an address of the same length using
+HMAC-SHA256 keyed with a secret. The mapping is consistent across runs for the
+same key and cannot be reversed without the key. Different addresses may map to
+the same pseudonym, the probability is negligible for any realistic dataset
+unless the OUI is preserved and the remaining part is short.
+*/
+func Pseudonymize(addr []byte, key []byte, opts PseudonymizeOptions) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(addr)
+	sum := mac.Sum(nil)
+
+	r := make([]byte, len(addr))
+	// Addresses up to 32 bytes are supported, IPoIB is the longest with 20.
+	copy(r, sum)
+
+	switch {
+	case opts.PreserveOUI:
+		copy(r[:OUILen], addr)
+	case opts.PreserveBits && len(addr) > 0:
+		r[0] = r[0]&^(bitGroup|bitLocal) | addr[0]&(bitGroup|bitLocal)
+	}
+
+	return r
+}
+
+/*
+Mask returns a copy of an address with all bytes but the first keep ones
+zeroed. Mask(addr, 3) hides the NIC specific part of an EUI leaving the OUI.
+*/
+func Mask(addr []byte, keep int) []byte {
+	r := make([]byte, len(addr))
+	copy(r, addr[:min(max(keep, 0), len(addr))])
+
+	return r
+}
@@ -0,0 +1,46 @@
+package hwaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestPseudonymize(t *testing.T) {
+	t.Parallel()
+
+	addr := []byte{0x03, 0x1B, 0x21, 0x0A, 0x0B, 0x0C}
+	key := []byte("secret")
+
+	plain := hwaddr.Pseudonymize(addr, key, hwaddr.PseudonymizeOptions{PreserveOUI: false, PreserveBits: false})
+	assert.Len(t, plain, len(addr))
+	assert.NotEqual(t, addr, plain)
+	assert.Equal(t, plain, hwaddr.Pseudonymize(addr, key, hwaddr.PseudonymizeOptions{}))
+	assert.NotEqual(t, plain, hwaddr.Pseudonymize(addr, []byte("other"), hwaddr.PseudonymizeOptions{}))
+
+	oui := hwaddr.Pseudonymize(addr, key, hwaddr.PseudonymizeOptions{PreserveOUI: true, PreserveBits: false})
+	assert.Equal(t, addr[:3], oui[:3])
+	assert.Equal(t, plain[3:], oui[3:])
+
+	bits := hwaddr.Pseudonymize(addr, key, hwaddr.PseudonymizeOptions{PreserveOUI: false, PreserveBits: true})
+	assert.True(t, hwaddr.IsMulticast(bits))
+	assert.True(t, hwaddr.IsLocal(bits))
+	assert.Equal(t, plain[0]&^0x03, bits[0]&^0x03)
+	assert.Equal(t, plain[1:], bits[1:])
+
+	ipoib := make([]byte, hwaddr.IPoIBLen)
+	assert.Len(t, hwaddr.Pseudonymize(ipoib, key, hwaddr.PseudonymizeOptions{}), hwaddr.IPoIBLen)
+}
+
+func TestMask(t *testing.T) {
+	t.Parallel()
+
+	addr := []byte{0x00, 0x1B, 0x21, 0x0A, 0x0B, 0x0C}
+
+	assert.Equal(t, []byte{0x00, 0x1B, 0x21, 0x00, 0x00, 0x00}, hwaddr.Mask(addr, 3))
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, hwaddr.Mask(addr, 0))
+	assert.Equal(t, addr, hwaddr.Mask(addr, 10))
+	assert.Equal(t, []byte{0x00, 0x1B, 0x21, 0x0A, 0x0B, 0x0C}, addr)
+}
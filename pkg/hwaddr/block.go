@@ -0,0 +1,132 @@
+package hwaddr
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// NibbleBits is the number of bits of a hex digit.
+const NibbleBits = 4
+
+var (
+	ErrBlockOutOfRange = errors.New("index is out of block range")
+	ErrInvalidBlock    = errors.New("invalid block")
+)
+
+/*
+Block is a range of [EUI64] sharing the first Bits bits of Prefix. Registry
+assignments are nibble-aligned: 24 bits for MA-L, 28 bits for MA-M and 36 bits
+for MA-S (OUI-36).
+*/
+type Block struct {
+	Prefix EUI64
+	Bits   int
+}
+
+/*
+ParseBlock parses a hex prefix like 70B3D5E75 or 70:B3:D5:E7:5 into a [Block].
+Separators from [-:.] are ignored.
+*/
+func ParseBlock(s string) (Block, error) {
+	return parseBlock(s, EUI64HexLen-1)
+}
+
+// parseBlock parses a hex prefix of up to maxDigits digits.
+func parseBlock(s string, maxDigits int) (Block, error) {
+	var hexDigits strings.Builder
+	for _, r := range s {
+		switch r {
+		case ':', '-', '.':
+			continue
+		default:
+			hexDigits.WriteRune(r)
+		}
+	}
+
+	digits := hexDigits.String()
+	if len(digits) == 0 || len(digits) > maxDigits {
+		return Block{}, fmt.Errorf(
+			"%w %q: expected 1 to %d hex digits, got %d", ErrInvalidBlock, s, maxDigits, len(digits),
+		)
+	}
+
+	n, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return Block{}, fmt.Errorf("%w %q: %w", ErrInvalidBlock, s, err)
+	}
+
+	prefixBits := len(digits) * NibbleBits
+
+	return Block{Prefix: EUI64FromUint64(n << (64 - prefixBits)), Bits: prefixBits}, nil
+}
+
+/*
+ParsePrefix parses a hex prefix or an address followed by a length in bits like
+00:1b:21:00:00:00/20 into a [Block]. Unlike [ParseBlock] a whole EUI-64 is
+accepted. The length may be any number of bits up to the number of bits given,
+bits past it are cleared.
+*/
+func ParsePrefix(s string) (Block, error) {
+	addr, length, found := strings.Cut(s, "/")
+	if !found {
+		return Block{}, fmt.Errorf("%w %q: expected a prefix length", ErrInvalidBlock, s)
+	}
+
+	block, err := parseBlock(addr, EUI64HexLen)
+	if err != nil {
+		return Block{}, err
+	}
+
+	n, err := strconv.Atoi(length)
+	if err != nil || n < 1 || n > block.Bits {
+		return Block{}, fmt.Errorf(
+			"%w %q: expected a prefix length of 1 to %d bits, got %q", ErrInvalidBlock, s, block.Bits, length,
+		)
+	}
+
+	return Block{Prefix: EUI64FromUint64(block.Prefix.Uint64() & (^uint64(0) << (64 - n))), Bits: n}, nil
+}
+
+// Size returns the number of addresses in the block.
+func (b Block) Size() uint64 {
+	return 1 << (64 - b.Bits)
+}
+
+// Contains reports whether an address belongs to the block.
+func (b Block) Contains(a EUI64) bool {
+	return bits.LeadingZeros64(a.Uint64()^b.Prefix.Uint64()) >= b.Bits
+}
+
+// Overlaps reports whether the blocks share any address, i.e. one of them
+// contains the other.
+func (b Block) Overlaps(other Block) bool {
+	return bits.LeadingZeros64(b.Prefix.Uint64()^other.Prefix.Uint64()) >= min(b.Bits, other.Bits)
+}
+
+// Nth returns an n-th address of the block counting from zero.
+func (b Block) Nth(n uint64) (EUI64, error) {
+	if n >= b.Size() {
+		return EUI64{}, fmt.Errorf("%w: %d >= %d", ErrBlockOutOfRange, n, b.Size())
+	}
+
+	return EUI64FromUint64(b.Prefix.Uint64() | n), nil
+}
+
+// Index returns a position of an address within the block.
+func (b Block) Index(a EUI64) (uint64, error) {
+	if !b.Contains(a) {
+		return 0, fmt.Errorf("%w: %s does not belong to %s", ErrBlockOutOfRange, a, b)
+	}
+
+	return a.Uint64() &^ b.Prefix.Uint64(), nil
+}
+
+// String returns the prefix as upper-case hex digits, the way IEEE registries
+// list assignments.
+func (b Block) String() string {
+	digits := b.Bits / NibbleBits
+	return strings.ToUpper(AsPlain(b.Prefix[:]))[:digits]
+}
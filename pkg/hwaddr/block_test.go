@@ -0,0 +1,138 @@
+package hwaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestParseBlock(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		want  hwaddr.Block
+		size  uint64
+	}{
+		{"70B3D5", hwaddr.Block{Prefix: hwaddr.EUI64{0x70, 0xB3, 0xD5}, Bits: 24}, 1 << 40},
+		{"70:b3:d5:e7:5", hwaddr.Block{Prefix: hwaddr.EUI64{0x70, 0xB3, 0xD5, 0xE7, 0x50}, Bits: 36}, 1 << 28},
+		{"8C1F649", hwaddr.Block{Prefix: hwaddr.EUI64{0x8C, 0x1F, 0x64, 0x90}, Bits: 28}, 1 << 36},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := hwaddr.ParseBlock(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.size, got.Size())
+		})
+	}
+}
+
+func TestParseBlockInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "::", "70B3D5TT", "70B3D57ED0001234"} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := hwaddr.ParseBlock(input)
+			require.ErrorIs(t, err, hwaddr.ErrInvalidBlock)
+		})
+	}
+}
+
+func TestBlockNth(t *testing.T) {
+	t.Parallel()
+
+	block, err := hwaddr.ParseBlock("70B3D57ED")
+	require.NoError(t, err)
+
+	first, err := block.Nth(0)
+	require.NoError(t, err)
+	assert.Equal(t, hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7E, 0xD0, 0x00, 0x00, 0x00}, first)
+
+	last, err := block.Nth(block.Size() - 1)
+	require.NoError(t, err)
+	assert.Equal(t, hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7E, 0xDF, 0xFF, 0xFF, 0xFF}, last)
+
+	_, err = block.Nth(block.Size())
+	require.ErrorIs(t, err, hwaddr.ErrBlockOutOfRange)
+
+	idx, err := block.Index(hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7E, 0xD0, 0x00, 0x01, 0x00})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x100), idx)
+
+	assert.False(t, block.Contains(hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7E, 0xE0}))
+	assert.Equal(t, "70B3D57ED", block.String())
+}
+
+func TestParsePrefix(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		want  hwaddr.Block
+	}{
+		{"00:1b:21:00:00:00/20", hwaddr.Block{Prefix: hwaddr.EUI64{0x00, 0x1B, 0x20}, Bits: 20}},
+		{"001B21/24", hwaddr.Block{Prefix: hwaddr.EUI64{0x00, 0x1B, 0x21}, Bits: 24}},
+		{"70-B3-D5-7E-FF-FF/30", hwaddr.Block{Prefix: hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7C}, Bits: 30}},
+		{"ff/1", hwaddr.Block{Prefix: hwaddr.EUI64{0x80}, Bits: 1}},
+		{"02:1b:21:ff:fe:0a:0b:0c/24", hwaddr.Block{Prefix: hwaddr.EUI64{0x02, 0x1B, 0x21}, Bits: 24}},
+		{
+			"021B21FFFE0A0B0C/64",
+			hwaddr.Block{Prefix: hwaddr.EUI64{0x02, 0x1B, 0x21, 0xFF, 0xFE, 0x0A, 0x0B, 0x0C}, Bits: 64},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := hwaddr.ParsePrefix(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePrefixInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"001B21", "001B21/", "001B21/0", "001B21/25", "001B21/x", "zz/4", "021B21FFFE0A0B0C0D/24",
+	} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := hwaddr.ParsePrefix(input)
+			require.ErrorIs(t, err, hwaddr.ErrInvalidBlock)
+		})
+	}
+}
+
+func TestBlockOverlaps(t *testing.T) {
+	t.Parallel()
+
+	query, err := hwaddr.ParsePrefix("00:1b:21:00:00:00/20")
+	require.NoError(t, err)
+
+	for input, want := range map[string]bool{
+		"001B21":    true,
+		"001B2FFFF": true,
+		"001B":      true,
+		"001B3":     false,
+		"0":         true,
+		"1":         false,
+	} {
+		block, perr := hwaddr.ParseBlock(input)
+		require.NoError(t, perr)
+		assert.Equal(t, want, query.Overlaps(block), input)
+		assert.Equal(t, want, block.Overlaps(query), input)
+	}
+}
@@ -0,0 +1,72 @@
+package hwaddr
+
+// BluetoothAddrType is a kind of Bluetooth device address (BD_ADDR).
+type BluetoothAddrType int
+
+const (
+	BluetoothPublic BluetoothAddrType = iota
+	BluetoothStaticRandom
+	BluetoothResolvablePrivate
+	BluetoothNonResolvablePrivate
+	BluetoothReservedRandom
+)
+
+func (t BluetoothAddrType) String() string {
+	switch t {
+	case BluetoothPublic:
+		return "public"
+	case BluetoothStaticRandom:
+		return "static_random"
+	case BluetoothResolvablePrivate:
+		return "resolvable_private"
+	case BluetoothNonResolvablePrivate:
+		return "non_resolvable_private"
+	case BluetoothReservedRandom:
+		return "reserved_random"
+	default:
+		return "unknown"
+	}
+}
+
+/*
+IsRandom reports whether the address is not IEEE assigned. The upper 24 bits of
+a random address are not an OUI and must not be looked up in registries.
+*/
+func (t BluetoothAddrType) IsRandom() bool {
+	return t != BluetoothPublic
+}
+
+/*
+ClassifyBluetooth determines a type of a BD_ADDR. Whether an address is public
+or random is conveyed out of band (e.g. TxAdd/RxAdd bits of an advertising PDU)
+so it has to be provided by the caller. Random addresses are classified by the
+two most significant bits:
+
+	11 static random
+	01 resolvable private
+	00 non-resolvable private
+	10 reserved
+*/
+func ClassifyBluetooth(a EUI48, random bool) BluetoothAddrType {
+	if !random {
+		return BluetoothPublic
+	}
+
+	const (
+		subtypeShift         = 6
+		subtypeStatic        = 0b11
+		subtypeResolvable    = 0b01
+		subtypeNonResolvable = 0b00
+	)
+
+	switch a[0] >> subtypeShift {
+	case subtypeStatic:
+		return BluetoothStaticRandom
+	case subtypeResolvable:
+		return BluetoothResolvablePrivate
+	case subtypeNonResolvable:
+		return BluetoothNonResolvablePrivate
+	default:
+		return BluetoothReservedRandom
+	}
+}
@@ -0,0 +1,35 @@
+package hwaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestClassifyBluetooth(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input  hwaddr.EUI48
+		random bool
+		want   hwaddr.BluetoothAddrType
+	}{
+		{hwaddr.EUI48{0xC0, 0x11, 0x22, 0x33, 0x44, 0x55}, false, hwaddr.BluetoothPublic},
+		{hwaddr.EUI48{0xC0, 0x11, 0x22, 0x33, 0x44, 0x55}, true, hwaddr.BluetoothStaticRandom},
+		{hwaddr.EUI48{0x4A, 0x11, 0x22, 0x33, 0x44, 0x55}, true, hwaddr.BluetoothResolvablePrivate},
+		{hwaddr.EUI48{0x3F, 0x11, 0x22, 0x33, 0x44, 0x55}, true, hwaddr.BluetoothNonResolvablePrivate},
+		{hwaddr.EUI48{0x80, 0x11, 0x22, 0x33, 0x44, 0x55}, true, hwaddr.BluetoothReservedRandom},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input.String(), func(t *testing.T) {
+			t.Parallel()
+
+			got := hwaddr.ClassifyBluetooth(tt.input, tt.random)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.random, got.IsRandom())
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package hwaddr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DUIDType is the leading 16-bit field of a DHCPv6 DUID (RFC 8415, RFC 6355).
+type DUIDType uint16
+
+const (
+	DUIDTypeLLT  DUIDType = 1
+	DUIDTypeEN   DUIDType = 2
+	DUIDTypeLL   DUIDType = 3
+	DUIDTypeUUID DUIDType = 4
+)
+
+func (t DUIDType) String() string {
+	switch t {
+	case DUIDTypeLLT:
+		return "DUID-LLT"
+	case DUIDTypeEN:
+		return "DUID-EN"
+	case DUIDTypeLL:
+		return "DUID-LL"
+	case DUIDTypeUUID:
+		return "DUID-UUID"
+	default:
+		return fmt.Sprintf("DUID(%d)", uint16(t))
+	}
+}
+
+// Hardware types of link-layer addresses in DUIDs from
+// https://www.iana.org/assignments/arp-parameters.
+const (
+	HardwareTypeEthernet uint16 = 1
+	HardwareTypeEUI64    uint16 = 27
+)
+
+const (
+	duidTypeLen = 2
+	duidLLLen   = 4  // type, hardware type
+	duidLLTLen  = 8  // type, hardware type, time
+	duidENLen   = 6  // type, enterprise number
+	duidUUIDLen = 18 // type, UUID
+	// DUIDMaxLen is the type followed by at most 128 octets of the identifier.
+	DUIDMaxLen = duidTypeLen + 128
+)
+
+// duidEpoch is the origin of the time of DUID-LLT.
+var duidEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC) //nolint: gochecknoglobals // constant
+
+var ErrUnsupportedDUIDType = errors.New("unsupported DUID type")
+
+/*
+DUID is a DHCP Unique Identifier. Supported types:
+
+	DUID-LLT:  0001:hardware type:time:link-layer address
+	DUID-EN:   0002:enterprise number:identifier
+	DUID-LL:   0003:hardware type:link-layer address
+	DUID-UUID: 0004:UUID                                  (18 bytes)
+
+where the time is seconds since midnight (UTC), January 1, 2000 modulo 2^32.
+*/
+type DUID []byte
+
+/*
+ParseDUID parses a DUID in colon, dash or plain form, a leading 0x is accepted.
+
+Supported formats:
+
+00:01:00:01:2c:9d:18:31:00:1b:21:0a:0b:0c
+00-03-00-01-00-1b-21-0a-0b-0c
+0x00030001001b210a0b0c
+*/
+func ParseDUID(s string) (DUID, error) {
+	d, err := parseHexString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(d) < duidTypeLen || len(d) > DUIDMaxLen {
+		return nil, ParseError{
+			Input: s,
+			Msg:   fmt.Sprintf("expected %d to %d bytes, got %d", duidTypeLen, DUIDMaxLen, len(d)),
+			Err:   ErrInputUnexpectedNumBytes,
+		}
+	}
+
+	var minLen int
+	switch t := DUID(d).Type(); t {
+	case DUIDTypeLLT:
+		minLen = duidLLTLen + 1
+	case DUIDTypeEN:
+		minLen = duidENLen + 1
+	case DUIDTypeLL:
+		minLen = duidLLLen + 1
+	case DUIDTypeUUID:
+		if len(d) != duidUUIDLen {
+			return nil, ParseError{
+				Input: s, Msg: fmt.Sprintf("%s requires %d bytes", t, duidUUIDLen), Err: ErrInputUnexpectedNumBytes,
+			}
+		}
+	default:
+		return nil, ParseError{Input: s, Msg: t.String(), Err: ErrUnsupportedDUIDType}
+	}
+	if len(d) < minLen {
+		return nil, ParseError{
+			Input: s, Msg: fmt.Sprintf("%s requires at least %d bytes", DUID(d).Type(), minLen), Err: ErrInputTooShort,
+		}
+	}
+
+	return d, nil
+}
+
+// NewDUIDLL builds a DUID-LL from an EUI-48 or an EUI-64.
+func NewDUIDLL(addr []byte) (DUID, error) {
+	hardwareType, err := duidHardwareType(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	d := make(DUID, duidLLLen, duidLLLen+len(addr))
+	binary.BigEndian.PutUint16(d, uint16(DUIDTypeLL))
+	binary.BigEndian.PutUint16(d[duidTypeLen:], hardwareType)
+
+	return append(d, addr...), nil
+}
+
+// NewDUIDLLT builds a DUID-LLT from an EUI-48 or an EUI-64 and the time the
+// DUID is generated at.
+func NewDUIDLLT(addr []byte, t time.Time) (DUID, error) {
+	const timeOffset = 4
+
+	hardwareType, err := duidHardwareType(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	d := make(DUID, duidLLTLen, duidLLTLen+len(addr))
+	binary.BigEndian.PutUint16(d, uint16(DUIDTypeLLT))
+	binary.BigEndian.PutUint16(d[duidTypeLen:], hardwareType)
+	// Wraps around modulo 2^32 as the RFC says.
+	binary.BigEndian.PutUint32(d[timeOffset:], uint32(int64(t.Sub(duidEpoch)/time.Second))) //nolint: gosec // intended
+
+	return append(d, addr...), nil
+}
+
+func duidHardwareType(addr []byte) (uint16, error) {
+	switch len(addr) {
+	case EUI48Len:
+		return HardwareTypeEthernet, nil
+	case EUI64Len:
+		return HardwareTypeEUI64, nil
+	default:
+		return 0, ParseError{
+			Input: AsColon(addr),
+			Msg:   fmt.Sprintf("expected %d or %d bytes, got %d", EUI48Len, EUI64Len, len(addr)),
+			Err:   ErrInputUnexpectedNumBytes,
+		}
+	}
+}
+
+// Type returns zero for DUIDs shorter than the type.
+func (d DUID) Type() DUIDType {
+	if len(d) < duidTypeLen {
+		return 0
+	}
+	return DUIDType(binary.BigEndian.Uint16(d))
+}
+
+// HardwareType returns the hardware type of a DUID-LLT or a DUID-LL.
+func (d DUID) HardwareType() (uint16, bool) {
+	switch {
+	case d.Type() == DUIDTypeLLT && len(d) > duidLLTLen, d.Type() == DUIDTypeLL && len(d) > duidLLLen:
+		return binary.BigEndian.Uint16(d[duidTypeLen:]), true
+	default:
+		return 0, false
+	}
+}
+
+// LinkLayerAddr returns the link-layer address of a DUID-LLT or a DUID-LL of
+// any hardware type.
+func (d DUID) LinkLayerAddr() ([]byte, bool) {
+	switch {
+	case d.Type() == DUIDTypeLLT && len(d) > duidLLTLen:
+		return d[duidLLTLen:], true
+	case d.Type() == DUIDTypeLL && len(d) > duidLLLen:
+		return d[duidLLLen:], true
+	default:
+		return nil, false
+	}
+}
+
+// EUI returns the link-layer address of a DUID-LLT or a DUID-LL when it is an
+// EUI-48 of the Ethernet hardware type or an EUI-64.
+func (d DUID) EUI() ([]byte, bool) {
+	hardwareType, _ := d.HardwareType()
+	addr, ok := d.LinkLayerAddr()
+
+	switch {
+	case ok && hardwareType == HardwareTypeEthernet && len(addr) == EUI48Len:
+		return addr, true
+	case ok && hardwareType == HardwareTypeEUI64 && len(addr) == EUI64Len:
+		return addr, true
+	default:
+		return nil, false
+	}
+}
+
+// Time returns the time a DUID-LLT was generated at.
+func (d DUID) Time() (time.Time, bool) {
+	const timeOffset = 4
+
+	if d.Type() != DUIDTypeLLT || len(d) <= duidLLTLen {
+		return time.Time{}, false
+	}
+
+	seconds := binary.BigEndian.Uint32(d[timeOffset:])
+
+	return duidEpoch.Add(time.Duration(seconds) * time.Second), true
+}
+
+// EnterpriseNumber returns the IANA private enterprise number of a DUID-EN.
+func (d DUID) EnterpriseNumber() (uint32, bool) {
+	if d.Type() != DUIDTypeEN || len(d) <= duidENLen {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(d[duidTypeLen:]), true
+}
+
+// Identifier returns the vendor assigned identifier of a DUID-EN.
+func (d DUID) Identifier() ([]byte, bool) {
+	if d.Type() != DUIDTypeEN || len(d) <= duidENLen {
+		return nil, false
+	}
+	return d[duidENLen:], true
+}
+
+// UUID returns the UUID of a DUID-UUID.
+func (d DUID) UUID() ([16]byte, bool) {
+	var uuid [16]byte
+
+	if d.Type() != DUIDTypeUUID || len(d) != duidUUIDLen {
+		return uuid, false
+	}
+	copy(uuid[:], d[duidTypeLen:])
+
+	return uuid, true
+}
+
+// Equivalent to ToString(d, []byte{':'}, 1).
+func (d DUID) String() string {
+	return AsColon(d)
+}
@@ -0,0 +1,150 @@
+package hwaddr_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestParseDUID(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input        string
+		typ          hwaddr.DUIDType
+		hardwareType uint16
+		addr         []byte
+	}{
+		{
+			"00:01:00:01:2c:9d:18:31:00:1b:21:0a:0b:0c",
+			hwaddr.DUIDTypeLLT, hwaddr.HardwareTypeEthernet, []byte{0x00, 0x1B, 0x21, 0x0A, 0x0B, 0x0C},
+		},
+		{
+			"00-03-00-01-00-1b-21-0a-0b-0c",
+			hwaddr.DUIDTypeLL, hwaddr.HardwareTypeEthernet, []byte{0x00, 0x1B, 0x21, 0x0A, 0x0B, 0x0C},
+		},
+		{
+			"0x0003001b001b21fffe0a0b0c",
+			hwaddr.DUIDTypeLL, hwaddr.HardwareTypeEUI64, []byte{0x00, 0x1B, 0x21, 0xFF, 0xFE, 0x0A, 0x0B, 0x0C},
+		},
+		{"000300060102", hwaddr.DUIDTypeLL, 6, []byte{0x01, 0x02}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := hwaddr.ParseDUID(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.typ, got.Type())
+
+			hardwareType, ok := got.HardwareType()
+			require.True(t, ok)
+			assert.Equal(t, tt.hardwareType, hardwareType)
+
+			addr, ok := got.LinkLayerAddr()
+			require.True(t, ok)
+			assert.Equal(t, tt.addr, addr)
+		})
+	}
+}
+
+func TestParseDUIDInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		err   error
+	}{
+		{"", hwaddr.ErrInputUnexpectedNumBytes},
+		{"00", hwaddr.ErrInputUnexpectedNumBytes},
+		{"00:05", hwaddr.ErrUnsupportedDUIDType},
+		{"00:05:00:01:02", hwaddr.ErrUnsupportedDUIDType},
+		{"00:01:00:01:2c:9d:18:31", hwaddr.ErrInputTooShort},
+		{"00:02:00:00:00:09", hwaddr.ErrInputTooShort},
+		{"00:04:01:02:03", hwaddr.ErrInputUnexpectedNumBytes},
+		{"00:03:00:01:00-1b", hwaddr.ErrInputUnbalanced},
+		{"0003000", hwaddr.ErrInputUnbalanced},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := hwaddr.ParseDUID(tt.input)
+			require.ErrorAs(t, err, new(hwaddr.ParseError))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err := hwaddr.ParseDUID("00")
+	require.ErrorContains(t, err, "expected 2 to 130 bytes, got 1")
+}
+
+func TestDUIDFields(t *testing.T) {
+	t.Parallel()
+
+	llt, err := hwaddr.ParseDUID("00:01:00:01:2c:9d:18:31:00:1b:21:0a:0b:0c")
+	require.NoError(t, err)
+	ts, ok := llt.Time()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2023, time.September, 20, 2, 40, 49, 0, time.UTC), ts)
+	_, ok = llt.EnterpriseNumber()
+	assert.False(t, ok)
+
+	en, err := hwaddr.ParseDUID("00:02:00:00:00:09:0c:c0:84:d3:03:00:09:12")
+	require.NoError(t, err)
+	number, ok := en.EnterpriseNumber()
+	require.True(t, ok)
+	assert.Equal(t, uint32(9), number)
+	identifier, ok := en.Identifier()
+	require.True(t, ok)
+	assert.Equal(t, []byte{0x0C, 0xC0, 0x84, 0xD3, 0x03, 0x00, 0x09, 0x12}, identifier)
+	_, ok = en.EUI()
+	assert.False(t, ok)
+
+	uuid, err := hwaddr.ParseDUID("0004f1c8f5d2c5a94b54a1c52b0a1d0e7f30")
+	require.NoError(t, err)
+	got, ok := uuid.UUID()
+	require.True(t, ok)
+	assert.Equal(t, byte(0xF1), got[0])
+	assert.Equal(t, byte(0x30), got[15])
+	assert.Equal(t, "DUID-UUID", uuid.Type().String())
+
+	// EUI is limited to Ethernet and EUI-64 hardware types.
+	other, err := hwaddr.ParseDUID("000300060102")
+	require.NoError(t, err)
+	_, ok = other.EUI()
+	assert.False(t, ok)
+}
+
+func TestNewDUID(t *testing.T) {
+	t.Parallel()
+
+	mac := []byte{0x00, 0x1B, 0x21, 0x0A, 0x0B, 0x0C}
+
+	ll, err := hwaddr.NewDUIDLL(mac)
+	require.NoError(t, err)
+	assert.Equal(t, "00:03:00:01:00:1b:21:0a:0b:0c", ll.String())
+
+	ts := time.Date(2023, time.September, 20, 2, 40, 49, 0, time.UTC)
+	llt, err := hwaddr.NewDUIDLLT(mac, ts)
+	require.NoError(t, err)
+	assert.Equal(t, "00:01:00:01:2c:9d:18:31:00:1b:21:0a:0b:0c", llt.String())
+
+	eui, ok := llt.EUI()
+	require.True(t, ok)
+	assert.Equal(t, mac, eui)
+
+	eui64, err := hwaddr.NewDUIDLL([]byte{0x00, 0x1B, 0x21, 0xFF, 0xFE, 0x0A, 0x0B, 0x0C})
+	require.NoError(t, err)
+	hardwareType, _ := eui64.HardwareType()
+	assert.Equal(t, hwaddr.HardwareTypeEUI64, hardwareType)
+
+	_, err = hwaddr.NewDUIDLL([]byte{0x01, 0x02})
+	require.ErrorIs(t, err, hwaddr.ErrInputUnexpectedNumBytes)
+}
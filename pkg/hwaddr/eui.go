@@ -6,15 +6,22 @@ Features:
 	- stringify an EUI specifying common formats
 	- produce EUI-64 modified from EUI-48
 	- produce an IPv6 address from EUI-64 and an IPv6 prefix
+	- parse Fibre Channel WWNs and extract the embedded OUI
+	- parse 20-byte IPoIB addresses and InfiniBand GUIDs
+	- find EUIs in arbitrary text
 */
 
 package hwaddr
 
 import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
 )
 
@@ -72,18 +79,29 @@ XX-XX-XX-XX-XX-XX-XX-XX
 XXXX.XXXX.XXXX
 XXXX.XXXX.XXXX.XXXX
 */
-//nolint: mnd,gocognit // fine
 func ParseAddr(s string) ([]byte, error) {
+	return parseAddr(s, EUI48Len, EUI64Len)
+}
+
+/*
+parseAddr implements the grammar of [ParseAddr] for addresses of given sizes in
+bytes. The smallest size goes first.
+*/
+//nolint: mnd,gocognit // fine
+func parseAddr(s string, sizes ...int) ([]byte, error) {
 	var err error
 
-	if len(s) < EUI48HexLen {
+	minLen := ByteHex * sizes[0]
+	maxLen := (ByteHex+1)*sizes[len(sizes)-1] - 1
+
+	if len(s) < minLen {
 		return nil, ParseError{
-			Input: s, Msg: fmt.Sprintf("input length must be >= %d, got %d", EUI48HexLen, len(s)), Err: ErrInputTooShort,
+			Input: s, Msg: fmt.Sprintf("input length must be >= %d, got %d", minLen, len(s)), Err: ErrInputTooShort,
 		}
 	}
-	if len(s) > 23 {
+	if len(s) > maxLen {
 		return nil, ParseError{
-			Input: s, Msg: fmt.Sprintf("input length must be <= %d, got %d", 23, len(s)), Err: ErrInputTooLong,
+			Input: s, Msg: fmt.Sprintf("input length must be <= %d, got %d", maxLen, len(s)), Err: ErrInputTooLong,
 		}
 	}
 
@@ -94,7 +112,7 @@ func ParseAddr(s string) ([]byte, error) {
 		}
 
 		n := (len(s) + 1) / 3
-		if n != EUI48Len && n != EUI64Len {
+		if !slices.Contains(sizes, n) {
 			return nil, ParseError{Input: s, Msg: "", Err: ErrInputUnexpectedNumBytes}
 		}
 
@@ -114,7 +132,7 @@ func ParseAddr(s string) ([]byte, error) {
 		}
 
 		n := 2 * (len(s) + 1) / 5
-		if n != EUI48Len && n != EUI64Len {
+		if !slices.Contains(sizes, n) {
 			return nil, ParseError{Input: s, Msg: "", Err: ErrInputUnexpectedNumBytes}
 		}
 
@@ -134,7 +152,7 @@ func ParseAddr(s string) ([]byte, error) {
 		}
 
 		n := len(s) / 2
-		if n != EUI48Len && n != EUI64Len {
+		if !slices.Contains(sizes, n) {
 			return nil, ParseError{Input: s, Msg: "", Err: ErrInputUnexpectedNumBytes}
 		}
 
@@ -182,7 +200,7 @@ func (a EUI48) EUI64Modified() EUI64 {
 	eui64[6] = a[4]
 	eui64[7] = a[5]
 
-	eui64[0] ^= 0x02
+	eui64[0] ^= bitLocal
 
 	return EUI64(eui64)
 }
@@ -206,6 +224,60 @@ func (a EUI64) String() string {
 	return AsColon(a[:])
 }
 
+// EUI64FromUint64 converts an integer into [EUI64] in network byte order.
+func EUI64FromUint64(n uint64) EUI64 {
+	var r EUI64
+	binary.BigEndian.PutUint64(r[:], n)
+	return r
+}
+
+// Uint64 returns the address as an integer in network byte order.
+func (a EUI64) Uint64() uint64 {
+	return binary.BigEndian.Uint64(a[:])
+}
+
+/*
+Reversed returns the address with the order of bytes swapped. Useful for
+identifiers that are transmitted least significant byte first, like LoRaWAN
+DevEUI and JoinEUI.
+*/
+func (a EUI64) Reversed() EUI64 {
+	var r EUI64
+	for i := range a {
+		r[len(a)-1-i] = a[i]
+	}
+	return r
+}
+
+const (
+	bitGroup = 0x01
+	bitLocal = 0x02
+)
+
+// IsMulticast reports whether the I/G (individual/group) bit of an address is
+// set.
+func IsMulticast(addr []byte) bool {
+	return len(addr) > 0 && addr[0]&bitGroup != 0
+}
+
+// IsLocal reports whether the U/L (universal/local) bit of an address is set,
+// i.e. the address is locally administered rather than assigned by a vendor.
+func IsLocal(addr []byte) bool {
+	return len(addr) > 0 && addr[0]&bitLocal != 0
+}
+
+/*
+Compare orders addresses numerically. Shorter addresses sort first so that all
+EUI-48 come before all EUI-64, addresses of the same length are compared byte by
+byte. The result is -1, 0 or +1 like [bytes.Compare].
+*/
+func Compare(a, b []byte) int {
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return bytes.Compare(a, b)
+}
+
 // AppendToPrefix writes [EUI64] into 8 least significant bytes of a prefix.
 func AppendToPrefix(prefix netip.Prefix, eui64 EUI64) netip.Addr {
 	prefixBytes := prefix.Addr().As16()
@@ -153,3 +153,76 @@ func TestEUI64Modified(t *testing.T) {
 		})
 	}
 }
+
+func TestEUI64Reversed(t *testing.T) {
+	t.Parallel()
+
+	input := hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7E, 0xD0, 0x00, 0x12, 0x34}
+	want := hwaddr.EUI64{0x34, 0x12, 0x00, 0xD0, 0x7E, 0xD5, 0xB3, 0x70}
+
+	assert.Equal(t, want, input.Reversed())
+	assert.Equal(t, input, input.Reversed().Reversed())
+}
+
+func TestEUI64Uint64(t *testing.T) {
+	t.Parallel()
+
+	input := hwaddr.EUI64{0x70, 0xB3, 0xD5, 0x7E, 0xD0, 0x00, 0x12, 0x34}
+
+	assert.Equal(t, uint64(0x70B3D57ED0001234), input.Uint64())
+	assert.Equal(t, input, hwaddr.EUI64FromUint64(input.Uint64()))
+}
+
+func TestAddressBits(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input     []byte
+		multicast bool
+		local     bool
+	}{
+		{[]byte{0x00, 0x1B, 0x21, 0x0A, 0x0B, 0x0C}, false, false},
+		{[]byte{0x01, 0x80, 0xC2, 0x00, 0x00, 0x0E}, true, false},
+		{[]byte{0x02, 0x42, 0xAC, 0x11, 0x00, 0x02}, false, true},
+		{[]byte{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, true, true},
+		{[]byte{}, false, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(hwaddr.AsColon(tt.input), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.multicast, hwaddr.IsMulticast(tt.input))
+			assert.Equal(t, tt.local, hwaddr.IsLocal(tt.input))
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"00:1b:21:0a:0b:0c", "00-1B-21-0A-0B-0C", 0},
+		{"00:1b:21:0a:0b:0c", "00:1b:21:0a:0b:0d", -1},
+		{"ff:ff:ff:ff:ff:ff", "00:00:00:00:00:00:00:00", -1},
+		{"00:00:00:00:00:00:00:01", "00:00:00:00:00:00:00:00", 1},
+		{"0a:00:00:00:00:00", "09:ff:ff:ff:ff:ff", 1},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := hwaddr.ParseAddr(tt.a)
+			require.NoError(t, err)
+			b, err := hwaddr.ParseAddr(tt.b)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, hwaddr.Compare(a, b))
+			assert.Equal(t, -tt.want, hwaddr.Compare(b, a))
+		})
+	}
+}
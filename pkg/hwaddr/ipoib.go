@@ -0,0 +1,127 @@
+package hwaddr
+
+import (
+	"encoding/binary"
+	"errors"
+	"net/netip"
+	"strings"
+)
+
+const (
+	IPoIBLen    = 20
+	IPoIBHexLen = 2 * IPoIBLen
+)
+
+/*
+HardwareAddr is a link-layer address of a variable length: EUI-48, EUI-64
+(including InfiniBand GUIDs) or a 20-byte IP over InfiniBand address.
+*/
+type HardwareAddr []byte
+
+/*
+ParseHardwareAddr parses a string of EUI48/EUI64/IPoIB into [HardwareAddr].
+On top of the formats of [ParseAddr] it accepts 20-byte IPoIB addresses as
+printed by `ip link` and InfiniBand GUIDs in the notations of ibstat and
+ibv_devinfo.
+
+Supported formats on top of [ParseAddr]:
+
+XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX
+XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX-XX
+XXXX.XXXX.XXXX.XXXX.XXXX.XXXX.XXXX.XXXX.XXXX.XXXX
+XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+XXXX:XXXX:XXXX:XXXX
+0xXXXXXXXXXXXXXXXX
+*/
+func ParseHardwareAddr(s string) (HardwareAddr, error) {
+	input := s
+
+	if rest, found := strings.CutPrefix(s, "0x"); found {
+		s = rest
+	}
+	// ibv_devinfo prints GUIDs as groups of four hex digits separated by colons.
+	if len(s) > 4 && s[4] == ':' {
+		s = strings.ReplaceAll(s, ":", ".")
+	}
+
+	addr, err := parseAddr(s, EUI48Len, EUI64Len, IPoIBLen)
+	if err != nil {
+		// Report the original input rather than the normalized one.
+		var parseErr ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Input = input
+			return nil, parseErr
+		}
+		return nil, err
+	}
+
+	return addr, nil
+}
+
+// IPoIB returns the address as [IPoIBAddr] if it is 20 bytes long.
+func (a HardwareAddr) IPoIB() (IPoIBAddr, bool) {
+	if len(a) != IPoIBLen {
+		return IPoIBAddr{}, false
+	}
+
+	return IPoIBAddr(a), true
+}
+
+/*
+OUI returns the IEEE company identifier of the address. For IPoIB addresses it
+is the identifier of the port GUID.
+*/
+func (a HardwareAddr) OUI() [OUILen]byte {
+	var oui [OUILen]byte
+
+	if ipoib, ok := a.IPoIB(); ok {
+		guid := ipoib.GUID()
+		copy(oui[:], guid[:])
+	} else {
+		copy(oui[:], a)
+	}
+
+	return oui
+}
+
+// Equivalent to ToString(a, []byte{':'}, 1).
+func (a HardwareAddr) String() string {
+	return AsColon(a)
+}
+
+/*
+IPoIBAddr is a hardware address of an IP over InfiniBand interface (RFC 4391):
+a byte of flags, 24-bit queue pair number (QPN) and 128-bit port GID. The GID
+consists of a 64-bit subnet prefix and a 64-bit port GUID (EUI-64).
+*/
+type IPoIBAddr [IPoIBLen]byte
+
+// Flags returns the reserved/flags byte preceding the QPN.
+func (a IPoIBAddr) Flags() byte {
+	return a[0]
+}
+
+// QPN returns the queue pair number.
+func (a IPoIBAddr) QPN() uint32 {
+	return binary.BigEndian.Uint32(a[0:4]) & 0xFFFFFF //nolint: mnd // 24 bits
+}
+
+// GID returns the port GID. GIDs share the layout of IPv6 addresses.
+func (a IPoIBAddr) GID() netip.Addr {
+	return netip.AddrFrom16([16]byte(a[4:20]))
+}
+
+// SubnetPrefix returns the upper 64 bits of the GID.
+func (a IPoIBAddr) SubnetPrefix() uint64 {
+	return binary.BigEndian.Uint64(a[4:12])
+}
+
+// GUID returns the port GUID, the lower 64 bits of the GID.
+func (a IPoIBAddr) GUID() EUI64 {
+	return EUI64(a[12:20])
+}
+
+// Equivalent to ToString(a[:], []byte{':'}, 1).
+func (a IPoIBAddr) String() string {
+	return AsColon(a[:])
+}
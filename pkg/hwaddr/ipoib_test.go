@@ -0,0 +1,89 @@
+package hwaddr_test
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestParseHardwareAddr(t *testing.T) {
+	t.Parallel()
+
+	ipoib := hwaddr.HardwareAddr{
+		0x80, 0x00, 0x02, 0x08, 0xFE, 0x80, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x02, 0xC9, 0x03, 0x00, 0x0A, 0x0B, 0x0C,
+	}
+	guid := hwaddr.HardwareAddr{0x00, 0x02, 0xC9, 0x03, 0x00, 0x0A, 0x0B, 0x0C}
+
+	cases := []struct {
+		input string
+		want  hwaddr.HardwareAddr
+	}{
+		{"00:AA:11:BB:22:CC", hwaddr.HardwareAddr{0x00, 0xAA, 0x11, 0xBB, 0x22, 0xCC}},
+		{"00aa.11bb.22cc", hwaddr.HardwareAddr{0x00, 0xAA, 0x11, 0xBB, 0x22, 0xCC}},
+		{"80:00:02:08:fe:80:00:00:00:00:00:00:00:02:c9:03:00:0a:0b:0c", ipoib},
+		{"80-00-02-08-fe-80-00-00-00-00-00-00-00-02-c9-03-00-0a-0b-0c", ipoib},
+		{"8000.0208.fe80.0000.0000.0000.0002.c903.000a.0b0c", ipoib},
+		{"80000208fe800000000000000002c903000a0b0c", ipoib},
+		{"0002:c903:000a:0b0c", guid},
+		{"0x0002c903000a0b0c", guid},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := hwaddr.ParseHardwareAddr(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseHardwareAddrInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		err   error
+	}{
+		{"80:00:02:08:fe:80:00:00:00:00:00:00:00:02:c9:03:00:0a:0b", hwaddr.ErrInputUnexpectedNumBytes},
+		{"80:00:02:08:fe:80:00:00:00:00:00:00:00:02:c9:03:00:0a:0b:0c:0d", hwaddr.ErrInputTooLong},
+		{"0002:c903:000a:0b0", hwaddr.ErrInputUnbalanced},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := hwaddr.ParseHardwareAddr(tt.input)
+			var parseErr hwaddr.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.input, parseErr.Input)
+		})
+	}
+}
+
+func TestIPoIBAddr(t *testing.T) {
+	t.Parallel()
+
+	addr, err := hwaddr.ParseHardwareAddr("80:00:02:08:fe:80:00:00:00:00:00:00:00:02:c9:03:00:0a:0b:0c")
+	require.NoError(t, err)
+
+	ipoib, ok := addr.IPoIB()
+	require.True(t, ok)
+	assert.Equal(t, byte(0x80), ipoib.Flags())
+	assert.Equal(t, uint32(0x000208), ipoib.QPN())
+	assert.Equal(t, netip.MustParseAddr("fe80::2:c903:a:b0c"), ipoib.GID())
+	assert.Equal(t, uint64(0xFE80000000000000), ipoib.SubnetPrefix())
+	assert.Equal(t, hwaddr.EUI64{0x00, 0x02, 0xC9, 0x03, 0x00, 0x0A, 0x0B, 0x0C}, ipoib.GUID())
+	assert.Equal(t, [3]byte{0x00, 0x02, 0xC9}, addr.OUI())
+
+	_, ok = hwaddr.HardwareAddr{0x00, 0xAA, 0x11, 0xBB, 0x22, 0xCC}.IPoIB()
+	assert.False(t, ok)
+}
@@ -0,0 +1,241 @@
+package hwaddr
+
+import (
+	"bufio"
+	"io"
+	"slices"
+)
+
+// Match is an EUI found in a text.
+type Match struct {
+	// Start and End are byte offsets of the match within the text.
+	Start int
+	End   int
+	Text  string
+	Addr  []byte
+}
+
+/*
+FindAll returns all EUI48/EUI64 in a text in any notation supported by
+[ParseAddr]. An address must be delimited from the surrounding text by
+non-alphanumeric characters. Sequences of hex groups that are longer than
+an EUI-64 are skipped as a whole rather than matched partially.
+*/
+func FindAll(s string) []Match {
+	var matches []Match
+
+	for i := 0; i < len(s); i++ {
+		if !isHexDigit(s[i]) || !isLeftBoundary(s, i) {
+			continue
+		}
+
+		n := matchLen(s, i)
+		if n == 0 {
+			continue
+		}
+
+		addr, err := ParseAddr(s[i : i+n])
+		if err != nil {
+			continue
+		}
+
+		matches = append(matches, Match{Start: i, End: i + n, Text: s[i : i+n], Addr: addr})
+		i += n - 1
+	}
+
+	return matches
+}
+
+/*
+ReplaceAll returns a copy of a text with every EUI found by [FindAll] replaced
+by the return value of a function.
+*/
+func ReplaceAll(s string, repl func(m Match) string) string {
+	matches := FindAll(s)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var (
+		buf  = make([]byte, 0, len(s))
+		last int
+	)
+
+	for _, m := range matches {
+		buf = append(buf, s[last:m.Start]...)
+		buf = append(buf, repl(m)...)
+		last = m.End
+	}
+	buf = append(buf, s[last:]...)
+
+	return string(buf)
+}
+
+/*
+Scanner reads a text line by line and yields EUIs found by [FindAll]. Memory
+usage is bounded by the longest line, see [bufio.Scanner].
+*/
+type Scanner struct {
+	lines   *bufio.Scanner
+	lineN   int
+	matches []Match
+	current Match
+}
+
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{
+		lines:   bufio.NewScanner(r),
+		lineN:   0,
+		matches: nil,
+		current: Match{Start: 0, End: 0, Text: "", Addr: nil},
+	}
+}
+
+// Buffer sets the initial buffer and the maximum line length, see
+// [bufio.Scanner.Buffer].
+func (s *Scanner) Buffer(buf []byte, maxLen int) {
+	s.lines.Buffer(buf, maxLen)
+}
+
+// Scan advances the scanner to the next EUI. It returns false at the end of
+// the input or on an error.
+func (s *Scanner) Scan() bool {
+	for len(s.matches) == 0 {
+		if !s.lines.Scan() {
+			return false
+		}
+		s.lineN++
+		s.matches = FindAll(s.lines.Text())
+	}
+
+	s.current = s.matches[0]
+	s.matches = s.matches[1:]
+
+	return true
+}
+
+// Match returns the most recent EUI found by [Scanner.Scan].
+func (s *Scanner) Match() Match {
+	return s.current
+}
+
+// Line returns the number of the line of the most recent match counting from
+// one.
+func (s *Scanner) Line() int {
+	return s.lineN
+}
+
+// Text returns the line of the most recent match.
+func (s *Scanner) Text() string {
+	return s.lines.Text()
+}
+
+// Err returns the first non-EOF error encountered by the scanner.
+func (s *Scanner) Err() error {
+	return s.lines.Err() //nolint: wrapcheck // transparent wrapper of bufio.Scanner
+}
+
+// matchLen returns the length of an EUI starting at a given offset or zero.
+func matchLen(s string, i int) int {
+	const (
+		dotGroupLen = 4
+		eui48Groups = 3
+		eui64Groups = 4
+	)
+
+	if n := groupsLen(s, i, ByteHex, EUI48Len, EUI64Len, ':', '-'); n > 0 {
+		return n
+	}
+	if n := groupsLen(s, i, dotGroupLen, eui48Groups, eui64Groups, '.'); n > 0 {
+		return n
+	}
+
+	end := i
+	for end < len(s) && isHexDigit(s[end]) {
+		end++
+	}
+	if n := end - i; (n == EUI48HexLen || n == EUI64HexLen) && isRightBoundary(s, end, 0) {
+		return n
+	}
+
+	return 0
+}
+
+/*
+groupsLen returns the length of a sequence of either minGroups or maxGroups
+groups of groupLen hex digits delimited by the same separator from seps.
+*/
+func groupsLen(s string, i, groupLen, minGroups, maxGroups int, seps ...byte) int {
+	if i+groupLen >= len(s) || !isHexRun(s, i, groupLen) {
+		return 0
+	}
+
+	sep := s[i+groupLen]
+	if !slices.Contains(seps, sep) {
+		return 0
+	}
+
+	groups := 1
+	end := i + groupLen
+	for groups < maxGroups && end < len(s) && s[end] == sep && isHexRun(s, end+1, groupLen) {
+		groups++
+		end += 1 + groupLen
+	}
+
+	if groups != minGroups && groups != maxGroups {
+		return 0
+	}
+	if !isRightBoundary(s, end, sep) {
+		return 0
+	}
+
+	return end - i
+}
+
+func isLeftBoundary(s string, i int) bool {
+	if i == 0 {
+		return true
+	}
+	if isAlnum(s[i-1]) {
+		return false
+	}
+	// A separator preceded by a hex digit continues a longer sequence.
+	if s[i-1] == ':' || s[i-1] == '-' || s[i-1] == '.' {
+		return i < 2 || !isHexDigit(s[i-2])
+	}
+
+	return true
+}
+
+// isRightBoundary reports whether a match may end before s[end]. sep is the
+// separator of the match, zero for the plain notation.
+func isRightBoundary(s string, end int, sep byte) bool {
+	if end == len(s) {
+		return true
+	}
+	if isAlnum(s[end]) {
+		return false
+	}
+
+	return sep == 0 || s[end] != sep || end+1 == len(s) || !isHexDigit(s[end+1])
+}
+
+func isHexRun(s string, i, n int) bool {
+	if i+n > len(s) {
+		return false
+	}
+	for j := i; j < i+n; j++ {
+		if !isHexDigit(s[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func isAlnum(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
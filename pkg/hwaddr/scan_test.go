@@ -0,0 +1,95 @@
+package hwaddr_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestFindAll(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"00:AA:11:BB:22:CC", []string{"00:AA:11:BB:22:CC"}},
+		{"ether 00:aa:11:bb:22:cc txqueuelen 1000", []string{"00:aa:11:bb:22:cc"}},
+		{
+			"Internet  10.0.0.1  5  00aa.11bb.22cc  ARPA  Vlan10",
+			[]string{"00aa.11bb.22cc"},
+		},
+		{
+			`{"src":"00-AA-11-BB-22-CC","dst":"00aa11bb22cc33dd"}`,
+			[]string{"00-AA-11-BB-22-CC", "00aa11bb22cc33dd"},
+		},
+		{"lladdr 00:aa:11:bb:22:cc:33:dd.", []string{"00:aa:11:bb:22:cc:33:dd"}},
+		{"a=00:aa:11:bb:22:cc,b=00aa.11bb.22cc.33dd;", []string{"00:aa:11:bb:22:cc", "00aa.11bb.22cc.33dd"}},
+		// Sequences that are not EUIs as a whole.
+		{"00:aa:11:bb:22:cc:33", nil},
+		{"00:aa:11:bb:22:cc:33:dd:44", nil},
+		{"00:aa:11-bb:22:cc", nil},
+		{"x00:aa:11:bb:22:cc", nil},
+		{"00:aa:11:bb:22:ccx", nil},
+		{"0000.1111.2222.3333.4444", nil},
+		{"sha1 da39a3ee5e6b4b0d3255bfef95601890afd80709", nil},
+		{"00aa11bb22cc3", nil},
+		{"12:34:56 fe80::1", nil},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, m := range hwaddr.FindAll(tt.input) {
+				assert.Equal(t, m.Text, tt.input[m.Start:m.End])
+				want, err := hwaddr.ParseAddr(m.Text)
+				require.NoError(t, err)
+				assert.Equal(t, want, m.Addr)
+				got = append(got, m.Text)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	t.Parallel()
+
+	input := "from 00-AA-11-BB-22-CC to 00aa.11bb.22cc.33dd via 00:aa:11:bb:22:cc:33"
+	got := hwaddr.ReplaceAll(input, func(m hwaddr.Match) string {
+		return hwaddr.AsColon(m.Addr)
+	})
+	assert.Equal(t, "from 00:aa:11:bb:22:cc to 00:aa:11:bb:22:cc:33:dd via 00:aa:11:bb:22:cc:33", got)
+}
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	input := "first 00:aa:11:bb:22:cc\nnone\n\n00aa11bb22cc and 00-aa-11-bb-22-cd\n"
+	scanner := hwaddr.NewScanner(strings.NewReader(input))
+
+	type found struct {
+		line  int
+		start int
+		text  string
+	}
+
+	var got []found
+	for scanner.Scan() {
+		got = append(got, found{scanner.Line(), scanner.Match().Start, scanner.Match().Text})
+	}
+	require.NoError(t, scanner.Err())
+
+	assert.Equal(t, []found{
+		{1, 6, "00:aa:11:bb:22:cc"},
+		{4, 0, "00aa11bb22cc"},
+		{4, 17, "00-aa-11-bb-22-cd"},
+	}, got)
+}
@@ -0,0 +1,214 @@
+package hwaddr
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/*
+Classification describes a well-known address or a block of addresses used by
+a protocol or a virtualization platform. Group holds the decoded group number
+or VRID when HasGroup is true.
+*/
+type Classification struct {
+	Protocol string
+	Label    string
+	Group    int
+	HasGroup bool
+}
+
+type wellKnownBlock struct {
+	prefix   EUI48
+	bits     int
+	protocol string
+	label    string
+	// decode returns a classification specific to the address. Optional.
+	decode func(a EUI48) Classification
+}
+
+func (b wellKnownBlock) contains(a EUI48) bool {
+	var prefix, addr [8]byte
+	copy(prefix[:], b.prefix[:])
+	copy(addr[:], a[:])
+
+	diff := EUI64(prefix).Uint64() ^ EUI64(addr).Uint64()
+
+	return bits.LeadingZeros64(diff) >= b.bits
+}
+
+func (b wellKnownBlock) classify(a EUI48) Classification {
+	if b.decode != nil {
+		return b.decode(a)
+	}
+
+	return Classification{Protocol: b.protocol, Label: b.label, Group: 0, HasGroup: false}
+}
+
+// Most of the blocks do not need a decoder.
+var wellKnownBlocks = []wellKnownBlock{ //nolint: gochecknoglobals,exhaustruct,mnd // lookup table
+	{
+		prefix: EUI48{0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF}, bits: 48,
+		protocol: "broadcast", label: "Broadcast",
+	},
+	{
+		prefix: EUI48{0x00, 0x00, 0x5E}, bits: 24,
+		protocol: "IANA", label: "IANA unicast",
+	},
+	{
+		prefix: EUI48{0x00, 0x00, 0x5E, 0x00, 0x01}, bits: 40,
+		protocol: "VRRP", label: "VRRP virtual router (IPv4)",
+		decode: func(a EUI48) Classification {
+			return groupClassification("VRRP", "VRRP virtual router (IPv4) VRID %d", int(a[5]))
+		},
+	},
+	{
+		prefix: EUI48{0x00, 0x00, 0x5E, 0x00, 0x02}, bits: 40,
+		protocol: "VRRPv6", label: "VRRP virtual router (IPv6)",
+		decode: func(a EUI48) Classification {
+			return groupClassification("VRRPv6", "VRRP virtual router (IPv6) VRID %d", int(a[5]))
+		},
+	},
+	{
+		prefix: EUI48{0x01, 0x00, 0x5E}, bits: 25,
+		protocol: "IPv4 multicast", label: "IPv4 multicast",
+	},
+	{
+		prefix: EUI48{0x33, 0x33}, bits: 16,
+		protocol: "IPv6 multicast", label: "IPv6 multicast",
+	},
+	{
+		prefix: EUI48{0x00, 0x00, 0x0C, 0x07, 0xAC}, bits: 40,
+		protocol: "HSRP", label: "Cisco HSRP virtual router",
+		decode: func(a EUI48) Classification {
+			return groupClassification("HSRP", "Cisco HSRP virtual router group %d", int(a[5]))
+		},
+	},
+	{
+		prefix: EUI48{0x00, 0x00, 0x0C, 0x9F, 0xF0}, bits: 36,
+		protocol: "HSRPv2", label: "Cisco HSRPv2 virtual router",
+		decode: func(a EUI48) Classification {
+			return groupClassification(
+				"HSRPv2", "Cisco HSRPv2 virtual router group %d", int(a[4]&0x0F)<<8|int(a[5]),
+			)
+		},
+	},
+	{
+		prefix: EUI48{0x00, 0x07, 0xB4, 0x00}, bits: 30,
+		protocol: "GLBP", label: "Cisco GLBP virtual forwarder",
+		decode: func(a EUI48) Classification {
+			c := groupClassification("GLBP", "Cisco GLBP group %d", int(a[3]&0x03)<<8|int(a[4]))
+			c.Label = fmt.Sprintf("%s forwarder %d", c.Label, a[5])
+			return c
+		},
+	},
+	{
+		prefix: EUI48{0x01, 0x00, 0x0C, 0xCC, 0xCC, 0xCC}, bits: 48,
+		protocol: "CDP", label: "Cisco CDP, VTP, DTP, PAgP, UDLD",
+	},
+	{
+		prefix: EUI48{0x01, 0x00, 0x0C, 0xCC, 0xCC, 0xCD}, bits: 48,
+		protocol: "PVST+", label: "Cisco Shared Spanning Tree Protocol (PVST+)",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x00}, bits: 44,
+		protocol: "IEEE 802.1", label: "IEEE 802.1 reserved link-local",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x00}, bits: 48,
+		protocol: "STP", label: "Spanning Tree Protocol (nearest customer bridge)",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x01}, bits: 48,
+		protocol: "PAUSE", label: "IEEE 802.3x Ethernet flow control",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x02}, bits: 48,
+		protocol: "LACP", label: "Slow protocols (LACP, Ethernet OAM)",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x03}, bits: 48,
+		protocol: "EAPOL", label: "IEEE 802.1X port access entity",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x08}, bits: 48,
+		protocol: "STP", label: "Spanning Tree Protocol (provider bridge)",
+	},
+	{
+		prefix: EUI48{0x01, 0x80, 0xC2, 0x00, 0x00, 0x0E}, bits: 48,
+		protocol: "LLDP", label: "LLDP, PTP (nearest bridge)",
+	},
+	{
+		prefix: EUI48{0x00, 0x50, 0x56}, bits: 24,
+		protocol: "VMware", label: "VMware virtual machine (static)",
+	},
+	{
+		prefix: EUI48{0x00, 0x0C, 0x29}, bits: 24,
+		protocol: "VMware", label: "VMware virtual machine (generated)",
+	},
+	{
+		prefix: EUI48{0x00, 0x05, 0x69}, bits: 24,
+		protocol: "VMware", label: "VMware virtual machine (legacy)",
+	},
+	{
+		prefix: EUI48{0x00, 0x15, 0x5D}, bits: 24,
+		protocol: "Hyper-V", label: "Microsoft Hyper-V virtual machine",
+	},
+	{
+		prefix: EUI48{0x52, 0x54, 0x00}, bits: 24,
+		protocol: "QEMU", label: "QEMU/KVM virtual machine",
+	},
+	{
+		prefix: EUI48{0x00, 0x16, 0x3E}, bits: 24,
+		protocol: "Xen", label: "Xen virtual machine",
+	},
+	{
+		prefix: EUI48{0x08, 0x00, 0x27}, bits: 24,
+		protocol: "VirtualBox", label: "Oracle VirtualBox virtual machine",
+	},
+	{
+		prefix: EUI48{0x00, 0x1C, 0x42}, bits: 24,
+		protocol: "Parallels", label: "Parallels virtual machine",
+	},
+	{
+		prefix: EUI48{0x02, 0x42}, bits: 16,
+		protocol: "Docker", label: "Docker container",
+	},
+}
+
+func groupClassification(protocol, labelFormat string, group int) Classification {
+	return Classification{
+		Protocol: protocol,
+		Label:    fmt.Sprintf(labelFormat, group),
+		Group:    group,
+		HasGroup: true,
+	}
+}
+
+/*
+Classify matches an EUI-48 against a built-in table of well-known addresses and
+blocks: broadcast, IANA (VRRP), Cisco first hop redundancy protocols (HSRP,
+GLBP), IEEE 802.1 link-local protocols (STP, LACP, LLDP), CDP, IP multicast and
+default blocks of hypervisors and container runtimes. The most specific block
+wins. Returns false when nothing matches.
+*/
+func Classify(addr []byte) (Classification, bool) {
+	a, err := EUI48FromBytes(addr)
+	if err != nil {
+		return Classification{}, false
+	}
+
+	var best *wellKnownBlock
+
+	for i := range wellKnownBlocks {
+		block := &wellKnownBlocks[i]
+		if block.contains(a) && (best == nil || block.bits > best.bits) {
+			best = block
+		}
+	}
+
+	if best == nil {
+		return Classification{}, false
+	}
+
+	return best.classify(a), true
+}
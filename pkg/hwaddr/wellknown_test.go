@@ -0,0 +1,71 @@
+package hwaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input    string
+		protocol string
+		label    string
+		group    int
+		hasGroup bool
+	}{
+		{"ff:ff:ff:ff:ff:ff", "broadcast", "Broadcast", 0, false},
+		{"00:00:5e:00:01:0a", "VRRP", "VRRP virtual router (IPv4) VRID 10", 10, true},
+		{"00:00:5e:00:02:ff", "VRRPv6", "VRRP virtual router (IPv6) VRID 255", 255, true},
+		{"00:00:5e:00:53:00", "IANA", "IANA unicast", 0, false},
+		{"00:00:0c:07:ac:00", "HSRP", "Cisco HSRP virtual router group 0", 0, true},
+		{"00:00:0c:9f:f1:2c", "HSRPv2", "Cisco HSRPv2 virtual router group 300", 300, true},
+		{"00:07:b4:01:02:03", "GLBP", "Cisco GLBP group 258 forwarder 3", 258, true},
+		{"01:00:0c:cc:cc:cc", "CDP", "Cisco CDP, VTP, DTP, PAgP, UDLD", 0, false},
+		{"01:80:c2:00:00:00", "STP", "Spanning Tree Protocol (nearest customer bridge)", 0, false},
+		{"01:80:c2:00:00:02", "LACP", "Slow protocols (LACP, Ethernet OAM)", 0, false},
+		{"01:80:c2:00:00:0e", "LLDP", "LLDP, PTP (nearest bridge)", 0, false},
+		{"01:80:c2:00:00:0d", "IEEE 802.1", "IEEE 802.1 reserved link-local", 0, false},
+		{"01:00:5e:7f:ff:fa", "IPv4 multicast", "IPv4 multicast", 0, false},
+		{"33:33:00:00:00:01", "IPv6 multicast", "IPv6 multicast", 0, false},
+		{"52:54:00:12:34:56", "QEMU", "QEMU/KVM virtual machine", 0, false},
+		{"02:42:ac:11:00:02", "Docker", "Docker container", 0, false},
+		{"00:15:5d:01:02:03", "Hyper-V", "Microsoft Hyper-V virtual machine", 0, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			addr, err := hwaddr.ParseAddr(tt.input)
+			require.NoError(t, err)
+
+			got, ok := hwaddr.Classify(addr)
+			require.True(t, ok)
+			assert.Equal(t, hwaddr.Classification{
+				Protocol: tt.protocol, Label: tt.label, Group: tt.group, HasGroup: tt.hasGroup,
+			}, got)
+		})
+	}
+}
+
+func TestClassifyNoMatch(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"00:1b:21:0a:0b:0c", "01:00:5e:80:00:01", "00:00:5e:00:01:0a:00:00"} {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+
+			addr, err := hwaddr.ParseAddr(input)
+			require.NoError(t, err)
+
+			_, ok := hwaddr.Classify(addr)
+			assert.False(t, ok)
+		})
+	}
+}
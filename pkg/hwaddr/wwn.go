@@ -0,0 +1,204 @@
+package hwaddr
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	WWNLen         = 8
+	WWNExtendedLen = 16
+	OUILen         = 3
+)
+
+// NAA is a Network Address Authority, the top nibble of a Fibre Channel WWN.
+type NAA uint8
+
+const (
+	NAAIEEE               NAA = 1
+	NAAIEEEExtended       NAA = 2
+	NAARegistered         NAA = 5
+	NAARegisteredExtended NAA = 6
+)
+
+func (n NAA) String() string {
+	switch n {
+	case NAAIEEE:
+		return "IEEE"
+	case NAAIEEEExtended:
+		return "IEEE Extended"
+	case NAARegistered:
+		return "IEEE Registered"
+	case NAARegisteredExtended:
+		return "IEEE Registered Extended"
+	default:
+		return fmt.Sprintf("NAA(%d)", uint8(n))
+	}
+}
+
+var ErrUnsupportedNAA = errors.New("unsupported NAA")
+
+/*
+WWN is a Fibre Channel World Wide Name (WWNN/WWPN). Supported layouts:
+
+	NAA 1: 1000:OUI:VVVVVV                       (8 bytes)
+	NAA 2: 2VVV:OUI:VVVVVV                       (8 bytes)
+	NAA 5: 5:OUI:VVVVVVVVV                       (8 bytes)
+	NAA 6: 6:OUI:VVVVVVVVV:VVVVVVVVVVVVVVVV      (16 bytes)
+
+where OUI is an IEEE company identifier and V is vendor specific.
+*/
+type WWN []byte
+
+/*
+ParseWWN parses a WWN in colon, dash or plain form. A leading 0x is accepted
+to handle values from /sys/class/fc_host.
+
+Supported formats:
+
+XX:XX:XX:XX:XX:XX:XX:XX
+XX-XX-XX-XX-XX-XX-XX-XX
+XXXXXXXXXXXXXXXX
+0xXXXXXXXXXXXXXXXX
+XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX:XX
+XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+*/
+func ParseWWN(s string) (WWN, error) {
+	w, err := parseHexString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(w) != WWNLen && len(w) != WWNExtendedLen {
+		return nil, ParseError{
+			Input: s,
+			Msg:   fmt.Sprintf("expected %d or %d bytes, got %d", WWNLen, WWNExtendedLen, len(w)),
+			Err:   ErrInputUnexpectedNumBytes,
+		}
+	}
+
+	naa := WWN(w).NAA()
+	switch naa {
+	case NAAIEEE, NAAIEEEExtended, NAARegistered:
+		if len(w) != WWNLen {
+			return nil, ParseError{
+				Input: s, Msg: fmt.Sprintf("NAA %d requires %d bytes", naa, WWNLen), Err: ErrInputUnexpectedNumBytes,
+			}
+		}
+	case NAARegisteredExtended:
+		if len(w) != WWNExtendedLen {
+			return nil, ParseError{
+				Input: s, Msg: fmt.Sprintf("NAA %d requires %d bytes", naa, WWNExtendedLen), Err: ErrInputUnexpectedNumBytes,
+			}
+		}
+	default:
+		return nil, ParseError{Input: s, Msg: fmt.Sprintf("NAA %d", naa), Err: ErrUnsupportedNAA}
+	}
+
+	return w, nil
+}
+
+// parseHexString decodes hex in colon, dash or plain form with an optional
+// leading 0x.
+func parseHexString(s string) ([]byte, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+
+	if len(digits) > ByteHex && (digits[2] == ':' || digits[2] == '-') {
+		if (len(digits)+1)%3 != 0 {
+			return nil, ParseError{Input: s, Msg: "", Err: ErrInputUnbalanced}
+		}
+		var buf strings.Builder
+		for i := 0; i < len(digits); i += 3 {
+			if i+2 < len(digits) && digits[i+2] != digits[2] {
+				return nil, ParseError{Input: s, Msg: "mixed separators", Err: ErrInputUnbalanced}
+			}
+			buf.WriteString(digits[i : i+2])
+		}
+		digits = buf.String()
+	}
+
+	if len(digits)%2 != 0 {
+		return nil, ParseError{Input: s, Msg: "", Err: ErrInputUnbalanced}
+	}
+
+	data, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, ParseError{Input: s, Msg: "", Err: err}
+	}
+
+	return data, nil
+}
+
+// NAA returns the Network Address Authority of the WWN.
+func (w WWN) NAA() NAA {
+	return NAA(w[0] >> NibbleBits)
+}
+
+/*
+OUI returns the embedded IEEE company identifier. NAA 5 and NAA 6 place it right
+after the NAA nibble, NAA 1 and NAA 2 embed a whole EUI-48.
+*/
+//nolint: mnd // offsets are defined by the layout
+func (w WWN) OUI() [OUILen]byte {
+	var oui [OUILen]byte
+
+	switch w.NAA() {
+	case NAARegistered, NAARegisteredExtended:
+		// The OUI is shifted by a nibble right after the NAA.
+		n := binary.BigEndian.Uint32(w[0:4])
+		oui[0] = byte(n >> 20)
+		oui[1] = byte(n >> 12)
+		oui[2] = byte(n >> 4)
+	default:
+		copy(oui[:], w[2:5])
+	}
+
+	return oui
+}
+
+/*
+VendorSpecific returns the vendor assigned part of the WWN that follows the OUI:
+24 bits for NAA 1, 12+24 bits for NAA 2 (the port identifier after the NAA
+followed by the serial), 36 bits for NAA 5 and NAA 6.
+*/
+//nolint: mnd // offsets are defined by the layout
+func (w WWN) VendorSpecific() uint64 {
+	n := binary.BigEndian.Uint64(w[0:8])
+
+	switch w.NAA() {
+	case NAAIEEE:
+		return n & 0xFFFFFF
+	case NAAIEEEExtended:
+		return (n>>48&0xFFF)<<24 | n&0xFFFFFF
+	default:
+		return n & 0xFFFFFFFFF
+	}
+}
+
+// VendorSpecificExtension returns the trailing 64 bits of a NAA 6 WWN.
+func (w WWN) VendorSpecificExtension() uint64 {
+	if w.NAA() != NAARegisteredExtended {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(w[8:16])
+}
+
+// EUI48 returns the embedded MAC address of NAA 1 and NAA 2 WWNs.
+func (w WWN) EUI48() (EUI48, bool) {
+	switch w.NAA() {
+	case NAAIEEE, NAAIEEEExtended:
+		eui, _ := EUI48FromBytes(w[2:8])
+		return eui, true
+	default:
+		return EUI48{}, false
+	}
+}
+
+// Equivalent to ToString(w, []byte{':'}, 1).
+func (w WWN) String() string {
+	return AsColon(w)
+}
@@ -0,0 +1,90 @@
+package hwaddr_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ttl256/euivator/pkg/hwaddr"
+)
+
+func TestParseWWN(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input     string
+		naa       hwaddr.NAA
+		oui       [3]byte
+		vendor    uint64
+		extension uint64
+	}{
+		{"10:00:00:00:c9:12:34:56", hwaddr.NAAIEEE, [3]byte{0x00, 0x00, 0xC9}, 0x123456, 0},
+		{"21:00:00:1b:32:0a:0b:0c", hwaddr.NAAIEEEExtended, [3]byte{0x00, 0x1B, 0x32}, 0x1000A0B0C, 0},
+		{"50060b0000c26204", hwaddr.NAARegistered, [3]byte{0x00, 0x60, 0xB0}, 0x000C26204, 0},
+		{"0x500a098012345678", hwaddr.NAARegistered, [3]byte{0x00, 0xA0, 0x98}, 0x012345678, 0},
+		{
+			"60:0a:09:80:00:00:00:01:00:00:00:00:00:00:00:02",
+			hwaddr.NAARegisteredExtended, [3]byte{0x00, 0xA0, 0x98}, 0x000000001, 0x2,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := hwaddr.ParseWWN(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.naa, got.NAA())
+			assert.Equal(t, tt.oui, got.OUI())
+			assert.Equal(t, tt.vendor, got.VendorSpecific())
+			assert.Equal(t, tt.extension, got.VendorSpecificExtension())
+		})
+	}
+}
+
+func TestParseWWNInvalid(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		input string
+		err   error
+	}{
+		{"", hwaddr.ErrInputUnexpectedNumBytes},
+		{"30:00:00:00:c9:12:34:56", hwaddr.ErrUnsupportedNAA},
+		{"10:00:00:00:c9:12:34", hwaddr.ErrInputUnexpectedNumBytes},
+		{"10:00:00:00:c9:12:34:5", hwaddr.ErrInputUnbalanced},
+		{"10:00-00:00:c9:12:34:56", hwaddr.ErrInputUnbalanced},
+		{"60:0a:09:80:00:00:00:01", hwaddr.ErrInputUnexpectedNumBytes},
+		{"500a0980000000010000000000000002", hwaddr.ErrInputUnexpectedNumBytes},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := hwaddr.ParseWWN(tt.input)
+			require.ErrorAs(t, err, new(hwaddr.ParseError))
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestWWNEUI48(t *testing.T) {
+	t.Parallel()
+
+	wwn, err := hwaddr.ParseWWN("2001001b320a0b0c")
+	require.NoError(t, err)
+
+	eui, ok := wwn.EUI48()
+	require.True(t, ok)
+	assert.Equal(t, hwaddr.EUI48{0x00, 0x1B, 0x32, 0x0A, 0x0B, 0x0C}, eui)
+	assert.Equal(t, "20:01:00:1b:32:0a:0b:0c", wwn.String())
+	assert.Equal(t, "2001001b320a0b0c", hwaddr.AsPlain(wwn))
+
+	wwn, err = hwaddr.ParseWWN("50060b0000c26204")
+	require.NoError(t, err)
+
+	_, ok = wwn.EUI48()
+	assert.False(t, ok)
+}